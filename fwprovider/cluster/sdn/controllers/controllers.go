@@ -0,0 +1,336 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &sdnControllerResource{}
+	_ resource.ResourceWithConfigure   = &sdnControllerResource{}
+	_ resource.ResourceWithImportState = &sdnControllerResource{}
+)
+
+// NewSdnControllerResource creates a new instance of the sdn controller resource.
+// It is a helper function to simplify the provider implementation.
+func NewSdnControllerResource() resource.Resource {
+	return &sdnControllerResource{}
+}
+
+type sdnControllerResource struct {
+	client        proxmox.Client
+	strict        bool
+	quietNotFound bool
+}
+
+// Metadata returns the resource type name.
+func (r *sdnControllerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_controller"
+}
+
+// Schema defines the schema for the resource.
+func (r *sdnControllerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	recreatemodifier := objectplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+			if req.StateValue.IsNull() != req.PlanValue.IsNull() {
+				resp.RequiresReplace = true
+			}
+		},
+		"Changes of the SDN controller type require a resource replacement",
+		"Changes of the SDN controller type require a resource replacement",
+	)
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a Proxmox SDN controller.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the SDN controller.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"evpn": schema.SingleNestedAttribute{
+				Description: "EVPN SDN controller configuration.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"asn": schema.Int64Attribute{
+						Description: "Autonomous system number of the EVPN controller.",
+						Required:    true,
+					},
+					"peers": schema.ListAttribute{
+						Description: "List of peer (VTEP) IP addresses of the EVPN controller. " +
+							"Proxmox has no dedicated route reflector settings, the nodes always peer over iBGP " +
+							"with the listed addresses, so to use route reflectors list their addresses " +
+							"instead of the addresses of all nodes.",
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.ValueStringsAre(peerAddressValidator()),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(
+						path.MatchRoot("evpn"),
+						path.MatchRoot("bgp"),
+						path.MatchRoot("faucet"),
+					),
+				},
+				PlanModifiers: []planmodifier.Object{
+					recreatemodifier,
+				},
+			},
+			"bgp": schema.SingleNestedAttribute{
+				Description: "BGP SDN controller configuration. BGP controllers extend an EVPN controller " +
+					"with the BGP peering of a single node, e.g. towards external routers.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"node": schema.StringAttribute{
+						Description: "Name of the node the BGP controller peers from.",
+						Required:    true,
+					},
+					"asn": schema.Int64Attribute{
+						Description: "Autonomous system number of the BGP controller.",
+						Required:    true,
+					},
+					"peers": schema.ListAttribute{
+						Description: "List of peer IP addresses of the BGP controller.",
+						Required:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.ValueStringsAre(peerAddressValidator()),
+						},
+					},
+					"ebgp": schema.BoolAttribute{
+						Description: "Whether the peers are external BGP peers.",
+						Optional:    true,
+					},
+					"loopback": schema.StringAttribute{
+						Description: "Name of the loopback interface whose address is used as the source of " +
+							"the BGP sessions, e.g. `dummy1`, so that the peering doesn't depend on the state " +
+							"of a physical interface.",
+						Optional: true,
+						Validators: []validator.String{
+							interfaceNameValidator(),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					recreatemodifier,
+				},
+			},
+			"faucet": schema.SingleNestedAttribute{
+				Description: "Faucet (OpenFlow) SDN controller configuration. " +
+					"Proxmox doesn't define any settings for Faucet controllers, so the block is empty.",
+				Optional:   true,
+				Attributes: map[string]schema.Attribute{},
+				PlanModifiers: []planmodifier.Object{
+					recreatemodifier,
+				},
+			},
+		},
+	}
+}
+
+func (r *sdnControllerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource but got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+	r.strict = cfg.SDNStrict
+	r.quietNotFound = cfg.SDNQuietNotFound
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *sdnControllerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sdnControllerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().Controllers().Create(ctx, plan.exportToSdnControllerBody(ctx, &resp.Diagnostics))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating SDN Controller",
+			fmt.Sprintf("Failed to create SDN controller %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// read fetches the current state of the resource from the Proxmox API and updates the model.
+// It returns false if the controller doesn't exist.
+func (r *sdnControllerResource) read(ctx context.Context, model *sdnControllerResourceModel, diags *diag.Diagnostics) bool {
+	controller, err := r.client.Cluster().SDN().Controllers().Get(ctx, model.Name.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			if !r.quietNotFound {
+				sdn.AddWarning(diags, r.strict,
+					"SDN Controller Not Found",
+					fmt.Sprintf("SDN controller %s does not exist, removing it from the state", model.Name.ValueString()),
+				)
+			}
+
+			model.RemoveAllAttributes()
+
+			return false
+		}
+
+		diags.AddError(
+			"Error Reading SDN Controller",
+			fmt.Sprintf("Failed to read SDN controller %s: %s", model.Name.ValueString(), err),
+		)
+
+		return true
+	}
+
+	model.importFromSdnControllerBody(ctx, controller, diags)
+
+	return true
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sdnControllerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sdnControllerResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sdnControllerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sdnControllerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().Controllers().Update(ctx, plan.Name.ValueString(), plan.exportToUpdateBody(ctx, &resp.Diagnostics))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating SDN Controller",
+			fmt.Sprintf("Failed to update SDN controller %s: %s", plan.Name.ValueString(), err),
+		)
+		return
+	}
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sdnControllerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sdnControllerResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().Controllers().Delete(ctx, state.Name.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			if !r.quietNotFound {
+				sdn.AddWarning(&resp.Diagnostics, r.strict,
+					"SDN Controller Not Found",
+					fmt.Sprintf("SDN controller %s does not exist, skipping deletion", state.Name.ValueString()),
+				)
+			}
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Deleting SDN Controller",
+				fmt.Sprintf("Failed to delete SDN controller %s: %s", state.Name.ValueString(), err),
+			)
+		}
+		return
+	}
+}
+
+// ImportState imports an existing SDN controller by its name.
+func (r *sdnControllerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	controller, err := r.client.Cluster().SDN().Controllers().Get(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing SDN Controller",
+			fmt.Sprintf("Failed to read SDN controller %s: %s", req.ID, err),
+		)
+		return
+	}
+
+	model := sdnControllerResourceModel{Name: types.StringValue(req.ID)}
+
+	model.importFromSdnControllerBody(ctx, controller, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
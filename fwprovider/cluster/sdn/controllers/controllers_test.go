@@ -0,0 +1,59 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_controllers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceSdnControllerEvpnPeers(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	te.AddTemplateVars(map[string]any{
+		"ControllerName": fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999)),
+	})
+
+	// peers are deliberately not sorted, the order must survive the round trip
+	config := te.RenderConfig(`
+	resource "proxmox_virtual_environment_sdn_controller" "test" {
+		name = "{{.ControllerName}}"
+		evpn = {
+			asn   = 65000
+			peers = ["10.0.0.3", "10.0.0.1", "fd00::2"]
+		}
+	}`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_controller.test", map[string]string{
+						"evpn.asn":     "65000",
+						"evpn.peers.#": "3",
+						"evpn.peers.0": "10.0.0.3",
+						"evpn.peers.1": "10.0.0.1",
+						"evpn.peers.2": "fd00::2",
+					}),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
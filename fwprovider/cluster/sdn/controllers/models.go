@@ -0,0 +1,132 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/controllers"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sdnControllerResourceModel struct {
+	// Base attributes
+	Name   types.String              `tfsdk:"name"`
+	EVPN   *sdnControllerEvpnModel   `tfsdk:"evpn"`
+	BGP    *sdnControllerBgpModel    `tfsdk:"bgp"`
+	Faucet *sdnControllerFaucetModel `tfsdk:"faucet"`
+}
+
+type sdnControllerEvpnModel struct {
+	ASN   types.Int64 `tfsdk:"asn"`
+	Peers types.List  `tfsdk:"peers"`
+}
+
+type sdnControllerBgpModel struct {
+	Node     types.String `tfsdk:"node"`
+	ASN      types.Int64  `tfsdk:"asn"`
+	Peers    types.List   `tfsdk:"peers"`
+	EBGP     types.Bool   `tfsdk:"ebgp"`
+	Loopback types.String `tfsdk:"loopback"`
+}
+
+// sdnControllerFaucetModel is empty, as Proxmox doesn't define any settings for Faucet (OpenFlow) controllers.
+type sdnControllerFaucetModel struct{}
+
+// RemoveAllAttributes resets all attributes except the name.
+func (m *sdnControllerResourceModel) RemoveAllAttributes() {
+	*m = sdnControllerResourceModel{
+		Name: m.Name,
+	}
+}
+
+// exportToSdnControllerBody converts the resource model to a SDN controller body for API requests.
+func (m *sdnControllerResourceModel) exportToSdnControllerBody(ctx context.Context, diags *diag.Diagnostics) *controllers.SdnControllerBody {
+	result := &controllers.SdnControllerBody{
+		Name: m.Name.ValueString(),
+	}
+
+	var controllerType string
+	if m.EVPN != nil {
+		controllerType = "evpn"
+		result.Asn = m.EVPN.ASN.ValueInt64Pointer()
+		result.Peers = sdn.ConvertListToString(m.EVPN.Peers, ctx, diags)
+	} else if m.BGP != nil {
+		controllerType = "bgp"
+		result.Node = m.BGP.Node.ValueStringPointer()
+		result.Asn = m.BGP.ASN.ValueInt64Pointer()
+		result.Peers = sdn.ConvertListToString(m.BGP.Peers, ctx, diags)
+		result.Ebgp = proxmoxtypes.CustomBoolPtr(m.BGP.EBGP.ValueBoolPointer())
+		result.Loopback = m.BGP.Loopback.ValueStringPointer()
+	} else if m.Faucet != nil {
+		controllerType = "faucet"
+	}
+
+	result.Type = &controllerType
+
+	return result
+}
+
+// importFromSdnControllerBody populates the resource model from a SDN controller body.
+func (m *sdnControllerResourceModel) importFromSdnControllerBody(ctx context.Context, body *controllers.SdnControllerBody, diags *diag.Diagnostics) {
+	m.Name = types.StringValue(body.Name)
+
+	switch *body.Type {
+	case "evpn":
+		m.EVPN = &sdnControllerEvpnModel{
+			ASN:   types.Int64PointerValue(body.Asn),
+			Peers: sdn.ConvertStringToList(body.Peers, ctx, diags),
+		}
+	case "bgp":
+		m.BGP = &sdnControllerBgpModel{
+			Node:     types.StringPointerValue(body.Node),
+			ASN:      types.Int64PointerValue(body.Asn),
+			Peers:    sdn.ConvertStringToList(body.Peers, ctx, diags),
+			EBGP:     types.BoolPointerValue(body.Ebgp.PointerBool()),
+			Loopback: types.StringPointerValue(body.Loopback),
+		}
+	case "faucet":
+		m.Faucet = &sdnControllerFaucetModel{}
+	default:
+		diags.AddError(
+			"Invalid SDN Controller Type",
+			"SDN Controller type is not recognized: "+*body.Type,
+		)
+		return
+	}
+}
+
+func (m *sdnControllerResourceModel) exportToUpdateBody(ctx context.Context, diags *diag.Diagnostics) *controllers.SdnControllerBody {
+	body := m.exportToSdnControllerBody(ctx, diags)
+
+	// Add to delete_tab the optional fields that are unset in the request body.
+	var deleteTab []string
+
+	if m.BGP != nil {
+		if body.Ebgp == nil {
+			deleteTab = append(deleteTab, "ebgp")
+		}
+		if body.Loopback == nil {
+			deleteTab = append(deleteTab, "loopback")
+		}
+	}
+
+	if len(deleteTab) > 0 {
+		toDelete := strings.Join(deleteTab, ",")
+		body.Delete = &toDelete
+	}
+
+	// Update requests don't accept the "type" field, so we remove it if present.
+	body.Type = nil
+
+	return body
+}
@@ -0,0 +1,107 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_controllers
+
+import (
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSdnControllerEvpnPeersRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	diags := diag.Diagnostics{}
+
+	peers, d := types.ListValueFrom(ctx, types.StringType, []string{"10.0.0.3", "10.0.0.1", "fd00::2"})
+	require.False(t, d.HasError())
+
+	model := &sdnControllerResourceModel{
+		Name: types.StringValue("evpn1"),
+		EVPN: &sdnControllerEvpnModel{
+			ASN:   types.Int64Value(65000),
+			Peers: peers,
+		},
+	}
+
+	body := model.exportToSdnControllerBody(ctx, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, "evpn", *body.Type)
+	require.Equal(t, int64(65000), *body.Asn)
+	require.Equal(t, "10.0.0.3,10.0.0.1,fd00::2", *body.Peers)
+
+	imported := &sdnControllerResourceModel{}
+	imported.importFromSdnControllerBody(ctx, body, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, model, imported)
+}
+
+func TestSdnControllerBgpRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	diags := diag.Diagnostics{}
+
+	peers, d := types.ListValueFrom(ctx, types.StringType, []string{"192.0.2.1"})
+	require.False(t, d.HasError())
+
+	model := &sdnControllerResourceModel{
+		Name: types.StringValue("bgppve1"),
+		BGP: &sdnControllerBgpModel{
+			Node:     types.StringValue("pve1"),
+			ASN:      types.Int64Value(65001),
+			Peers:    peers,
+			EBGP:     types.BoolValue(true),
+			Loopback: types.StringValue("dummy1"),
+		},
+	}
+
+	body := model.exportToSdnControllerBody(ctx, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, "bgp", *body.Type)
+	require.Equal(t, "dummy1", *body.Loopback)
+
+	values, err := query.Values(body)
+	require.NoError(t, err)
+	require.Equal(t, "1", values.Get("ebgp"))
+
+	imported := &sdnControllerResourceModel{}
+	imported.importFromSdnControllerBody(ctx, body, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, model, imported)
+
+	// Removing the loopback from the configuration deletes it.
+	model.BGP.Loopback = types.StringNull()
+	require.Equal(t, "loopback", *model.exportToUpdateBody(ctx, &diags).Delete)
+}
+
+func TestSdnControllerFaucetRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	diags := diag.Diagnostics{}
+
+	model := &sdnControllerResourceModel{
+		Name:   types.StringValue("faucet1"),
+		Faucet: &sdnControllerFaucetModel{},
+	}
+
+	body := model.exportToSdnControllerBody(ctx, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, "faucet", *body.Type)
+	require.Nil(t, body.Asn)
+	require.Nil(t, body.Peers)
+
+	imported := &sdnControllerResourceModel{}
+	imported.importFromSdnControllerBody(ctx, body, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, model, imported)
+}
@@ -0,0 +1,31 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_controllers
+
+import (
+	"net/netip"
+	"regexp"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// interfaceNameRegex matches Linux network interface names, which are at most 15 characters long.
+var interfaceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,14}$`)
+
+// peerAddressValidator validates that a peer is a plain IPv4 or IPv6 address.
+func peerAddressValidator() validator.String {
+	return validators.NewParseValidator(netip.ParseAddr, "must be a valid IPv4 or IPv6 address")
+}
+
+// interfaceNameValidator validates that a value is a Linux network interface name, e.g. `lo` or `dummy1`.
+func interfaceNameValidator() validator.String {
+	return stringvalidator.RegexMatches(interfaceNameRegex,
+		"must be a network interface name of at most 15 letters, digits, `_`, `.` or `-`")
+}
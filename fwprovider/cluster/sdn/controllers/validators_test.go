@@ -0,0 +1,48 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_controllers
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceNameValidator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"loopback", "lo", true},
+		{"dummy", "dummy1", true},
+		{"vlan", "vmbr0.100", true},
+		{"longest", "abcdefghijklmno", true},
+		{"too long", "abcdefghijklmnop", false},
+		{"empty", "", false},
+		{"slash", "eth0/1", false},
+		{"space", "eth 0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &validator.StringResponse{}
+			interfaceNameValidator().ValidateString(t.Context(), validator.StringRequest{
+				Path:        path.Root("loopback"),
+				ConfigValue: types.StringValue(tt.value),
+			}, resp)
+			require.Equal(t, !tt.valid, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// AddWarning adds a warning to the diagnostics, or an error instead when the provider
+// is configured with `sdn_strict`, so that automation doesn't silently carry on.
+func AddWarning(diags *diag.Diagnostics, strict bool, summary string, detail string) {
+	if strict {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	diags.AddWarning(summary, detail)
+}
+
+// AddNotAvailableError adds an error telling how to enable SDN when the request failed because SDN is
+// not installed or not enabled on the cluster. It returns false, without adding anything, for other errors.
+func AddNotAvailableError(diags *diag.Diagnostics, err error) bool {
+	if !errors.Is(err, zones.ErrSDNNotAvailable) {
+		return false
+	}
+
+	diags.AddError(
+		"SDN Not Available",
+		"SDN is not installed or not enabled on the Proxmox cluster. Install the `libpve-network-perl` "+
+			"package on all nodes and make sure `/etc/network/interfaces` sources `/etc/network/interfaces.d/*`, "+
+			"see https://pve.proxmox.com/pve-docs/chapter-pvesdn.html#pvesdn_installation. Error: "+err.Error(),
+	)
+
+	return true
+}
+
+// AddAPIError adds an error for a failed request, with the detail followed by the error. When Proxmox
+// rejected individual parameters, an attribute error is added for each parameter instead, at the path
+// returned by attributePath. The parameters without an attribute are reported together in a single error.
+func AddAPIError(
+	diags *diag.Diagnostics,
+	summary string,
+	detail string,
+	err error,
+	attributePath func(param string) (path.Path, bool),
+) {
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) || len(httpErr.Errors) == 0 {
+		diags.AddError(summary, fmt.Sprintf("%s: %s", detail, err))
+		return
+	}
+
+	unmapped := false
+
+	for _, param := range slices.Sorted(maps.Keys(httpErr.Errors)) {
+		p, ok := attributePath(param)
+		if !ok {
+			unmapped = true
+			continue
+		}
+
+		diags.AddAttributeError(p, summary, fmt.Sprintf("%s: %s", detail, httpErr.Errors[param]))
+	}
+
+	if unmapped {
+		diags.AddError(summary, fmt.Sprintf("%s: %s", detail, err))
+	}
+}
+
+// AddBestEffortWarning reports a failed secondary request, made only to validate the configuration or to
+// enrich the state, as a warning with the detail followed by the error. Unlike AddWarning, it's never turned
+// into an error by `sdn_strict`: such requests can fail for reasons unrelated to the configuration, e.g. a
+// missing privilege of the API token or an older Proxmox version, which must not fail the whole operation.
+func AddBestEffortWarning(diags *diag.Diagnostics, summary string, detail string, err error) {
+	diags.AddWarning(summary, fmt.Sprintf("%s: %s", detail, err))
+}
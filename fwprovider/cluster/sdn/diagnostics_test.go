@@ -0,0 +1,107 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAddWarning(t *testing.T) {
+	t.Parallel()
+
+	var lenient diag.Diagnostics
+
+	AddWarning(&lenient, false, "SDN Zone Not Found", "gone")
+	require.False(t, lenient.HasError())
+	require.Equal(t, 1, lenient.WarningsCount())
+
+	var strict diag.Diagnostics
+
+	AddWarning(&strict, true, "SDN Zone Not Found", "gone")
+	require.True(t, strict.HasError())
+	require.Equal(t, 0, strict.WarningsCount())
+}
+
+func TestAddBestEffortWarning(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+
+	AddBestEffortWarning(&diags, "Unable to Validate SDN Zone IPAM", "Failed to list SDN IPAMs",
+		&api.HTTPError{Code: 403, Message: "Permission check failed"})
+	require.False(t, diags.HasError())
+	require.Equal(t, 1, diags.WarningsCount())
+	require.Equal(t, "Failed to list SDN IPAMs: received an HTTP 403 response - Reason: Permission check failed",
+		diags[0].Detail())
+}
+
+func TestAddAPIError(t *testing.T) {
+	t.Parallel()
+
+	attributePath := func(param string) (path.Path, bool) {
+		if param == "mtu" {
+			return path.Root("mtu"), true
+		}
+
+		return path.Empty(), false
+	}
+
+	validationErr := func(errs map[string]string) error {
+		return fmt.Errorf("error creating SDN zone: %w", &api.HTTPError{
+			Code:    400,
+			Message: "Parameter verification failed.",
+			Errors:  errs,
+		})
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		attributes []path.Path
+		errors     int
+	}{
+		{"plain error", errors.New("connection refused"), nil, 1},
+		{"field error", validationErr(map[string]string{"mtu": "value too low"}), []path.Path{path.Root("mtu")}, 1},
+		{"unknown field error", validationErr(map[string]string{"foo": "invalid"}), nil, 1},
+		{
+			"mixed field errors",
+			validationErr(map[string]string{"mtu": "value too low", "foo": "invalid"}),
+			[]path.Path{path.Root("mtu")},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+
+			AddAPIError(&diags, "Error Creating SDN Zone", "Failed to create SDN zone zone1", tt.err, attributePath)
+			require.Equal(t, tt.errors, diags.ErrorsCount())
+
+			var attributes []path.Path
+
+			for _, d := range diags {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					attributes = append(attributes, withPath.Path())
+				}
+			}
+
+			require.Equal(t, tt.attributes, attributes)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+var (
+	_ datasource.DataSource              = &sdnDnsDataSource{}
+	_ datasource.DataSourceWithConfigure = &sdnDnsDataSource{}
+)
+
+// NewSdnDnsDataSource creates a new instance of the sdn dns data source.
+// It is a helper function to simplify the provider implementation.
+func NewSdnDnsDataSource() datasource.DataSource {
+	return &sdnDnsDataSource{}
+}
+
+type sdnDnsDataSource struct {
+	client proxmox.Client
+}
+
+// Metadata returns the data source type name.
+func (d *sdnDnsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_dns"
+}
+
+// Schema defines the schema for the data source.
+func (d *sdnDnsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves information about a Proxmox SDN DNS server.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of the SDN DNS server.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the SDN DNS server.",
+				Computed:    true,
+			},
+			"url": schema.StringAttribute{
+				Description: "API URL of the SDN DNS server.",
+				Computed:    true,
+			},
+			"ttl": schema.Int32Attribute{
+				Description: "TTL of the records created by the SDN DNS server.",
+				Computed:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "Certificate SHA 256 fingerprint of the SDN DNS server API.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *sdnDnsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource but got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the SDN DNS server from the Proxmox API.
+func (d *sdnDnsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sdnDnsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, err := d.client.Cluster().SDN().DNS().Get(ctx, state.Name.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			resp.Diagnostics.AddError(
+				"SDN DNS Server Not Found",
+				fmt.Sprintf("SDN DNS server %s does not exist", state.Name.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Reading SDN DNS Server",
+				fmt.Sprintf("Failed to read SDN DNS server %s: %s", state.Name.ValueString(), err),
+			)
+		}
+		return
+	}
+
+	state.importFromSdnDnsBody(server)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
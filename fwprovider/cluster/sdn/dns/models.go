@@ -0,0 +1,30 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_dns
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/dns"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sdnDnsDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	URL         types.String `tfsdk:"url"`
+	TTL         types.Int32  `tfsdk:"ttl"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+// importFromSdnDnsBody populates the data source model from a SDN DNS body.
+func (m *sdnDnsDataSourceModel) importFromSdnDnsBody(body *dns.SdnDnsBody) {
+	m.Name = types.StringValue(body.Name)
+	m.Type = types.StringPointerValue(body.Type)
+	m.URL = types.StringPointerValue(body.URL)
+	m.TTL = types.Int32PointerValue(body.TTL)
+	m.Fingerprint = types.StringPointerValue(body.Fingerprint)
+}
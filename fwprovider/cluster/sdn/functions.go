@@ -0,0 +1,172 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &listToStringFunction{}
+	_ function.Function = &stringToListFunction{}
+	_ function.Function = &cidrAvailableFunction{}
+)
+
+// NewListToStringFunction creates the `sdn_list_to_string` provider function, which joins a list
+// into a comma-separated string, the way SDN node and peer lists are stored by Proxmox.
+func NewListToStringFunction() function.Function {
+	return &listToStringFunction{}
+}
+
+type listToStringFunction struct{}
+
+func (f *listToStringFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sdn_list_to_string"
+}
+
+func (f *listToStringFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Join a list into a SDN comma-separated string",
+		Description: "Joins a list of strings into a comma-separated string, the format used by Proxmox " +
+			"for SDN lists such as zone nodes or VXLAN peers.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "list",
+				Description: "List of strings to join.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *listToStringFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var list []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &list))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strings.Join(list, ",")))
+}
+
+// NewStringToListFunction creates the `sdn_string_to_list` provider function, which splits
+// a SDN comma-separated string into a list.
+func NewStringToListFunction() function.Function {
+	return &stringToListFunction{}
+}
+
+type stringToListFunction struct{}
+
+func (f *stringToListFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sdn_string_to_list"
+}
+
+func (f *stringToListFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a SDN comma-separated string into a list",
+		Description: "Splits a comma-separated string, the format used by Proxmox for SDN lists such as " +
+			"zone nodes or VXLAN peers, into a list of strings. The elements are trimmed and the empty ones " +
+			"are dropped, so an empty string results in an empty list.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "string",
+				Description: "Comma-separated string to split.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *stringToListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, splitList(value)))
+}
+
+// NewCIDRAvailableFunction creates the `sdn_cidr_available` provider function, which checks whether
+// a CIDR overlaps with any of the given subnets. Provider functions have no access to the Proxmox API,
+// so the existing subnets are passed as an argument rather than looked up.
+func NewCIDRAvailableFunction() function.Function {
+	return &cidrAvailableFunction{}
+}
+
+type cidrAvailableFunction struct{}
+
+func (f *cidrAvailableFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sdn_cidr_available"
+}
+
+func (f *cidrAvailableFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check that a CIDR doesn't overlap with existing subnets",
+		Description: "Returns whether a candidate CIDR is available, i.e. doesn't overlap with any of the given " +
+			"subnet CIDRs, e.g. the `cidr` of the SDN subnets of the configuration. Provider functions have no " +
+			"access to the Proxmox API, so the subnets must be passed explicitly. IPv4 and IPv6 CIDRs never overlap.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "cidr",
+				Description: "Candidate CIDR, e.g. `10.0.1.0/24`.",
+			},
+			function.ListParameter{
+				Name:        "subnets",
+				Description: "CIDRs of the existing subnets.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *cidrAvailableFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		cidr    string
+		subnets []string
+	)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidr, &subnets))
+	if resp.Error != nil {
+		return
+	}
+
+	candidate, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid CIDR %q: %s", cidr, err))
+		return
+	}
+
+	available := true
+
+	for _, subnet := range subnets {
+		existing, err := netip.ParsePrefix(subnet)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid subnet CIDR %q: %s", subnet, err))
+			return
+		}
+
+		if existing.Overlaps(candidate) {
+			available = false
+			break
+		}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, available))
+}
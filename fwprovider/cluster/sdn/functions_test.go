@@ -0,0 +1,110 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runFunction(t *testing.T, f function.Function, arg attr.Value) attr.Value {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringNull())}
+	if _, ok := arg.(types.String); ok {
+		resp.Result = function.NewResultData(types.ListNull(types.StringType))
+	}
+
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{arg}),
+	}, resp)
+	require.Nil(t, resp.Error)
+
+	return resp.Result.Value()
+}
+
+func TestListToStringFunction(t *testing.T) {
+	t.Parallel()
+
+	list := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("node1"),
+		types.StringValue("node2"),
+	})
+
+	require.Equal(t, types.StringValue("node1,node2"), runFunction(t, NewListToStringFunction(), list))
+	require.Equal(t, types.StringValue(""), runFunction(t, NewListToStringFunction(),
+		types.ListValueMust(types.StringType, []attr.Value{})))
+}
+
+func TestStringToListFunction(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("10.0.0.1"),
+		types.StringValue("10.0.0.2"),
+	}), runFunction(t, NewStringToListFunction(), types.StringValue("10.0.0.1,10.0.0.2")))
+	require.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}),
+		runFunction(t, NewStringToListFunction(), types.StringValue("")))
+	require.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("a"),
+		types.StringValue("b"),
+	}), runFunction(t, NewStringToListFunction(), types.StringValue(" a, b ,")))
+}
+
+func TestCIDRAvailableFunction(t *testing.T) {
+	t.Parallel()
+
+	subnets := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("10.0.0.0/24"),
+		types.StringValue("fd00::/64"),
+	})
+
+	tests := []struct {
+		name      string
+		cidr      string
+		subnets   types.List
+		available bool
+		errArg    int64
+	}{
+		{"disjoint", "10.0.1.0/24", subnets, true, -1},
+		{"overlapping supernet", "10.0.0.0/16", subnets, false, -1},
+		{"overlapping IPv6", "fd00::/56", subnets, false, -1},
+		{"no subnets", "10.0.0.0/24", types.ListValueMust(types.StringType, []attr.Value{}), true, -1},
+		{"invalid CIDR", "10.0.0.1", subnets, false, 0},
+		{"invalid subnet", "10.0.1.0/24", types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("10.0.0.0/33"),
+		}), false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &function.RunResponse{Result: function.NewResultData(types.BoolNull())}
+			NewCIDRAvailableFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.cidr), tt.subnets}),
+			}, resp)
+
+			if tt.errArg >= 0 {
+				require.NotNil(t, resp.Error)
+				require.NotNil(t, resp.Error.FunctionArgument)
+				require.Equal(t, tt.errArg, *resp.Error.FunctionArgument)
+
+				return
+			}
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, types.BoolValue(tt.available), resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,382 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_ipams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &sdnIpamMappingResource{}
+	_ resource.ResourceWithConfigure   = &sdnIpamMappingResource{}
+	_ resource.ResourceWithImportState = &sdnIpamMappingResource{}
+)
+
+// NewSdnIpamMappingResource creates a new instance of the sdn ipam mapping resource.
+// It is a helper function to simplify the provider implementation.
+func NewSdnIpamMappingResource() resource.Resource {
+	return &sdnIpamMappingResource{}
+}
+
+type sdnIpamMappingResource struct {
+	client        proxmox.Client
+	strict        bool
+	quietNotFound bool
+}
+
+// Metadata returns the resource type name.
+func (r *sdnIpamMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_ipam_mapping"
+}
+
+// Schema defines the schema for the resource.
+func (r *sdnIpamMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a static IP-to-MAC mapping in the IPAM of a SDN zone. " +
+			"With the `pve` IPAM and the `dnsmasq` DHCP, the mapping is used as a static DHCP reservation.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID("Identifier of the mapping, in the `<vnet>/<ip>` format."),
+			"zone": schema.StringAttribute{
+				Description: "Name of the SDN zone the VNet belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vnet": schema.StringAttribute{
+				Description: "Name of the SDN VNet of the mapping.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ip": schema.StringAttribute{
+				Description: "IP address of the mapping. It must belong to a subnet of the VNet.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.NewParseValidator(netip.ParseAddr, "must be a valid IPv4 or IPv6 address"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"mac": schema.StringAttribute{
+				Description: "MAC address the IP address is reserved for.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.NewParseValidator(net.ParseMAC, "must be a valid MAC address"),
+				},
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "Identifier of the VM or container the IP address is reserved for.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *sdnIpamMappingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource but got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+	r.strict = cfg.SDNStrict
+	r.quietNotFound = cfg.SDNQuietNotFound
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *sdnIpamMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sdnIpamMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().VNets().CreateIP(ctx, plan.VNet.ValueString(), plan.exportToSdnVnetIPBody())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating SDN IPAM Mapping",
+			fmt.Sprintf("Failed to create SDN IPAM mapping of %s in VNet %s: %s", plan.IP.ValueString(), plan.VNet.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(mappingID(plan.VNet.ValueString(), plan.IP.ValueString()))
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// read fetches the current state of the resource from the IPAM of the zone and updates the model.
+// It returns false if the mapping doesn't exist.
+func (r *sdnIpamMappingResource) read(ctx context.Context, model *sdnIpamMappingResourceModel, diags *diag.Diagnostics) bool {
+	zone, err := r.client.Cluster().SDN().Zones().Get(ctx, model.Zone.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			r.removeMissingMapping(model, diags,
+				fmt.Sprintf("SDN zone %s of the IPAM mapping %s does not exist, removing it from the state",
+					model.Zone.ValueString(), model.ID.ValueString()),
+			)
+
+			return false
+		}
+
+		diags.AddError(
+			"Error Reading SDN Zone",
+			fmt.Sprintf("Failed to read SDN zone %s of the IPAM mapping %s: %s", model.Zone.ValueString(), model.ID.ValueString(), err),
+		)
+
+		return true
+	}
+
+	if zone.Ipam == nil || *zone.Ipam == "" {
+		r.removeMissingMapping(model, diags,
+			fmt.Sprintf("SDN zone %s has no IPAM, so the IPAM mapping %s does not exist, removing it from the state",
+				zone.Name, model.ID.ValueString()),
+		)
+
+		return false
+	}
+
+	entries, err := r.client.Cluster().SDN().IPAMs().Status(ctx, *zone.Ipam)
+	if err != nil {
+		diags.AddError(
+			"Error Reading SDN IPAM",
+			fmt.Sprintf("Failed to read SDN IPAM %s: %s", *zone.Ipam, err),
+		)
+
+		return true
+	}
+
+	entry := findEntry(entries, model.VNet.ValueString(), model.IP.ValueString())
+	if entry == nil {
+		r.removeMissingMapping(model, diags,
+			fmt.Sprintf("SDN IPAM mapping %s does not exist, removing it from the state", model.ID.ValueString()),
+		)
+
+		return false
+	}
+
+	model.importFromSdnIpamStatusEntry(entry)
+
+	return true
+}
+
+// removeMissingMapping removes a mapping that no longer exists from the model.
+func (r *sdnIpamMappingResource) removeMissingMapping(model *sdnIpamMappingResourceModel, diags *diag.Diagnostics, detail string) {
+	if !r.quietNotFound {
+		sdn.AddWarning(diags, r.strict, "SDN IPAM Mapping Not Found", detail)
+	}
+
+	model.RemoveAllAttributes()
+}
+
+// findEntry returns the IPAM entry of the given IP address in the VNet, comparing the addresses
+// rather than their notations, or nil if there is none.
+func findEntry(entries []*ipams.SdnIpamStatusEntry, vnet string, ip string) *ipams.SdnIpamStatusEntry {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if entry.VNet != vnet {
+			continue
+		}
+
+		entryAddr, err := netip.ParseAddr(entry.IP)
+		if err == nil && entryAddr == addr {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sdnIpamMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sdnIpamMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sdnIpamMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sdnIpamMappingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().VNets().UpdateIP(ctx, plan.VNet.ValueString(), plan.exportToSdnVnetIPBody())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating SDN IPAM Mapping",
+			fmt.Sprintf("Failed to update SDN IPAM mapping %s: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sdnIpamMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sdnIpamMappingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := state.exportToSdnVnetIPBody()
+	// Delete requests don't accept the "vmid" field.
+	body.VMID = nil
+
+	err := r.client.Cluster().SDN().VNets().DeleteIP(ctx, state.VNet.ValueString(), body)
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			if !r.quietNotFound {
+				sdn.AddWarning(&resp.Diagnostics, r.strict,
+					"SDN IPAM Mapping Not Found",
+					fmt.Sprintf("SDN IPAM mapping %s does not exist, skipping deletion", state.ID.ValueString()),
+				)
+			}
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Deleting SDN IPAM Mapping",
+				fmt.Sprintf("Failed to delete SDN IPAM mapping %s: %s", state.ID.ValueString(), err),
+			)
+		}
+		return
+	}
+}
+
+// ImportState imports an existing SDN IPAM mapping by its `<vnet>/<ip>` identifier. The zone of the mapping
+// is the zone of its VNet.
+func (r *sdnIpamMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	vnet, ip, ok := strings.Cut(req.ID, "/")
+	if !ok || vnet == "" || ip == "" {
+		resp.Diagnostics.AddError(
+			"Invalid SDN IPAM Mapping Import ID",
+			fmt.Sprintf("Expected the import ID in the `<vnet>/<ip>` format, got %s", req.ID),
+		)
+		return
+	}
+
+	if _, err := netip.ParseAddr(ip); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid SDN IPAM Mapping Import ID",
+			fmt.Sprintf("Expected the IP address of the import ID %s to be a valid IPv4 or IPv6 address: %s", req.ID, err),
+		)
+		return
+	}
+
+	vnetBody, err := r.client.Cluster().SDN().VNets().Get(ctx, vnet)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing SDN IPAM Mapping",
+			fmt.Sprintf("Failed to read SDN VNet %s: %s", vnet, err),
+		)
+		return
+	}
+
+	if vnetBody.Zone == nil {
+		resp.Diagnostics.AddError(
+			"Error Importing SDN IPAM Mapping",
+			fmt.Sprintf("SDN VNet %s has no zone", vnet),
+		)
+		return
+	}
+
+	model := sdnIpamMappingResourceModel{
+		ID:   types.StringValue(req.ID),
+		Zone: types.StringPointerValue(vnetBody.Zone),
+		VNet: types.StringValue(vnet),
+		IP:   types.StringValue(ip),
+	}
+
+	// The missing mapping is reported as an error below, rather than as a warning.
+	diags := diag.Diagnostics{}
+
+	found := r.read(ctx, &model, &diags)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"SDN IPAM Mapping Not Found",
+			fmt.Sprintf("SDN IPAM mapping %s does not exist in the IPAM of zone %s", req.ID, *vnetBody.Zone),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
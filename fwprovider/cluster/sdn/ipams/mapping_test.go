@@ -0,0 +1,153 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_ipams
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+// newTestClient creates a client connected to a test server using the given handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) proxmox.Client {
+	t.Helper()
+
+	return proxmox.NewClient(sdntest.NewAPIClient(t, handler), nil, "")
+}
+
+func TestFindEntry(t *testing.T) {
+	t.Parallel()
+
+	entries := []*ipams.SdnIpamStatusEntry{
+		{Zone: "zone1", VNet: "vnet1", IP: "10.0.0.10"},
+		{Zone: "zone1", VNet: "vnet2", IP: "10.0.0.10"},
+		{Zone: "zone1", VNet: "vnet1", IP: "fd00::a"},
+	}
+
+	require.Same(t, entries[0], findEntry(entries, "vnet1", "10.0.0.10"))
+	require.Same(t, entries[1], findEntry(entries, "vnet2", "10.0.0.10"))
+	require.Same(t, entries[2], findEntry(entries, "vnet1", "fd00:0:0:0:0:0:0:a"))
+	require.Nil(t, findEntry(entries, "vnet1", "10.0.0.11"))
+	require.Nil(t, findEntry(entries, "vnet3", "10.0.0.10"))
+}
+
+func TestReadMissingZoneOrIPAM(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		zone func(w http.ResponseWriter)
+	}{
+		{"zone deleted", func(w http.ResponseWriter) {
+			http.Error(w, "", http.StatusNotFound)
+		}},
+		{"zone without IPAM", func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple"}}`))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnIpamMappingResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api2/json/cluster/sdn/zones/zone1", r.URL.Path)
+				tt.zone(w)
+			})}
+
+			model := sdnIpamMappingResourceModel{
+				ID:   types.StringValue("vnet1/10.0.0.10"),
+				Zone: types.StringValue("zone1"),
+				VNet: types.StringValue("vnet1"),
+				IP:   types.StringValue("10.0.0.10"),
+				MAC:  types.StringValue("BC:24:11:00:00:01"),
+			}
+			diags := diag.Diagnostics{}
+
+			require.False(t, r.read(t.Context(), &model, &diags), "the mapping must be reported as missing")
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, 1, diags.WarningsCount())
+			require.True(t, model.MAC.IsNull(), "the mapping must be removed from the state")
+		})
+	}
+}
+
+func TestImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/vnets/vnet1":
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet1","zone":"zone1"}}`))
+		case "/api2/json/cluster/sdn/zones/zone1":
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple","ipam":"pve"}}`))
+		case "/api2/json/cluster/sdn/ipams/pve/status":
+			_, _ = w.Write([]byte(`{"data":[{"zone":"zone1","vnet":"vnet1","ip":"10.0.0.10",` +
+				`"mac":"BC:24:11:00:00:01","vmid":100}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	r := &sdnIpamMappingResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tests := []struct {
+		name  string
+		id    string
+		found bool
+	}{
+		{"existing mapping", "vnet1/10.0.0.10", true},
+		{"unknown IP", "vnet1/10.0.0.11", false},
+		{"unknown VNet", "vnet2/10.0.0.10", false},
+		{"invalid IP", "vnet1/10.0.0", false},
+		{"missing VNet", "10.0.0.10", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			require.Equal(t, !tt.found, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if !tt.found {
+				return
+			}
+
+			var model sdnIpamMappingResourceModel
+
+			require.False(t, resp.State.Get(ctx, &model).HasError())
+			require.Equal(t, sdnIpamMappingResourceModel{
+				ID:   types.StringValue("vnet1/10.0.0.10"),
+				Zone: types.StringValue("zone1"),
+				VNet: types.StringValue("vnet1"),
+				IP:   types.StringValue("10.0.0.10"),
+				MAC:  types.StringValue("BC:24:11:00:00:01"),
+				VMID: types.Int64Value(100),
+			}, model)
+		})
+	}
+}
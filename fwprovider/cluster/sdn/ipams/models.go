@@ -0,0 +1,65 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_ipams
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/vnets"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sdnIpamMappingResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Zone types.String `tfsdk:"zone"`
+	VNet types.String `tfsdk:"vnet"`
+	IP   types.String `tfsdk:"ip"`
+	MAC  types.String `tfsdk:"mac"`
+	VMID types.Int64  `tfsdk:"vm_id"`
+}
+
+// mappingID returns the identifier of a mapping, in the "<vnet>/<ip>" format.
+func mappingID(vnet, ip string) string {
+	return fmt.Sprintf("%s/%s", vnet, ip)
+}
+
+// RemoveAllAttributes resets all attributes except the identifying ones.
+func (m *sdnIpamMappingResourceModel) RemoveAllAttributes() {
+	*m = sdnIpamMappingResourceModel{
+		ID:   m.ID,
+		Zone: m.Zone,
+		VNet: m.VNet,
+		IP:   m.IP,
+	}
+}
+
+// exportToSdnVnetIPBody converts the resource model to a SDN VNet IP body for API requests.
+func (m *sdnIpamMappingResourceModel) exportToSdnVnetIPBody() *vnets.SdnVnetIPBody {
+	return &vnets.SdnVnetIPBody{
+		Zone: m.Zone.ValueString(),
+		IP:   m.IP.ValueString(),
+		MAC:  m.MAC.ValueStringPointer(),
+		VMID: m.VMID.ValueInt64Pointer(),
+	}
+}
+
+// importFromSdnIpamStatusEntry populates the resource model from a SDN IPAM status entry.
+func (m *sdnIpamMappingResourceModel) importFromSdnIpamStatusEntry(entry *ipams.SdnIpamStatusEntry) {
+	m.ID = types.StringValue(mappingID(entry.VNet, m.IP.ValueString()))
+	m.Zone = types.StringValue(entry.Zone)
+	m.VNet = types.StringValue(entry.VNet)
+
+	// Proxmox may change the case of the MAC address, keep the configured one if it's the same address.
+	if entry.MAC == nil || !strings.EqualFold(*entry.MAC, m.MAC.ValueString()) {
+		m.MAC = types.StringPointerValue(entry.MAC)
+	}
+
+	m.VMID = types.Int64PointerValue(entry.VMID.PointerInt64())
+}
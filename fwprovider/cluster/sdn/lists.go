@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package sdn contains helpers shared by the SDN resources and data sources.
+//
+// List attributes of the SDN resources, e.g. `nodes`, `peers` or `exitnodes`, are stored by Proxmox as
+// comma-separated strings, which can't tell an empty list from an unset one. The attributes are therefore
+// always null when unset, never empty lists, and the schemas reject empty lists, which would otherwise be
+// read back as null and cause a diff. The `nodes` of the zones are the exception: an empty list of nodes,
+// e.g. from a filtered list of online nodes, means all nodes like an unset one, and is kept as is.
+package sdn
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ConvertListToString converts a Terraform list to a comma-separated string, or nil if the list is null,
+// unknown or empty.
+func ConvertListToString(list types.List, ctx context.Context, diags *diag.Diagnostics) *string {
+	if list.IsNull() || list.IsUnknown() || len(list.Elements()) == 0 {
+		return nil
+	}
+
+	strs := make([]types.String, 0, len(list.Elements()))
+	nodes_diags := list.ElementsAs(ctx, &strs, false)
+	diags.Append(nodes_diags...)
+
+	stringVals := make([]string, len(strs))
+	for i, v := range strs {
+		stringVals[i] = v.ValueString()
+	}
+	joined := strings.Join(stringVals, ",")
+	return &joined
+}
+
+// ConvertStringToList converts a comma-separated string to a Terraform list, or a null list of strings if
+// the string is unset or empty. The elements are trimmed, as the lists edited by hand may have spaces after
+// the commas, and the empty ones are dropped.
+func ConvertStringToList(value *string, ctx context.Context, diags *diag.Diagnostics) types.List {
+	if value == nil {
+		return types.ListNull(types.StringType)
+	}
+
+	parts := splitList(*value)
+	if len(parts) == 0 {
+		return types.ListNull(types.StringType)
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, types.StringType, parts)
+	diags.Append(listDiags...)
+
+	return list
+}
+
+// splitList splits a comma-separated string into its trimmed elements, dropping the empty ones.
+func splitList(value string) []string {
+	parts := []string{}
+
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return parts
+}
@@ -0,0 +1,56 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestConvertListToString(t *testing.T) {
+	t.Parallel()
+
+	diags := diag.Diagnostics{}
+
+	require.Nil(t, ConvertListToString(types.ListNull(types.StringType), t.Context(), &diags))
+	require.Nil(t, ConvertListToString(types.ListUnknown(types.StringType), t.Context(), &diags))
+	require.Nil(t, ConvertListToString(types.ListValueMust(types.StringType, []attr.Value{}), t.Context(), &diags))
+
+	list := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("pve1"), types.StringValue("pve2")})
+	require.Equal(t, ptr.Ptr("pve1,pve2"), ConvertListToString(list, t.Context(), &diags))
+	require.False(t, diags.HasError())
+}
+
+func TestConvertStringToList(t *testing.T) {
+	t.Parallel()
+
+	diags := diag.Diagnostics{}
+
+	// Unset lists are always null lists of strings, never empty lists.
+	require.Equal(t, types.ListNull(types.StringType), ConvertStringToList(nil, t.Context(), &diags))
+	require.Equal(t, types.ListNull(types.StringType), ConvertStringToList(ptr.Ptr(""), t.Context(), &diags))
+
+	require.Equal(t,
+		types.ListValueMust(types.StringType, []attr.Value{types.StringValue("pve1"), types.StringValue("pve2")}),
+		ConvertStringToList(ptr.Ptr("pve1,pve2"), t.Context(), &diags),
+	)
+
+	// Whitespace around the elements and empty elements aren't part of the list.
+	require.Equal(t,
+		types.ListValueMust(types.StringType, []attr.Value{types.StringValue("pve1"), types.StringValue("pve2")}),
+		ConvertStringToList(ptr.Ptr(" pve1, pve2 ,"), t.Context(), &diags),
+	)
+	require.Equal(t, types.ListNull(types.StringType), ConvertStringToList(ptr.Ptr(" , "), t.Context(), &diags))
+	require.False(t, diags.HasError())
+}
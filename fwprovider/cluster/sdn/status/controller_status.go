@@ -0,0 +1,181 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &sdnControllerStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &sdnControllerStatusDataSource{}
+)
+
+// evpnSummaryCommand prints the BGP EVPN sessions of a node. Proxmox has no API for the FRR status,
+// so it is queried over SSH, with sudo when it's available. The `try_sudo` helper isn't used, as it
+// splits its argument into words and would pass the quoted vtysh command as separate arguments.
+const evpnSummaryCommand = `if command -v sudo >/dev/null 2>&1 && sudo -n true 2>/dev/null; then ` +
+	`sudo -n vtysh -c "show bgp l2vpn evpn summary json"; ` +
+	`else vtysh -c "show bgp l2vpn evpn summary json"; fi`
+
+// NewSdnControllerStatusDataSource creates a new instance of the sdn controller status data source.
+// It is a helper function to simplify the provider implementation.
+func NewSdnControllerStatusDataSource() datasource.DataSource {
+	return &sdnControllerStatusDataSource{}
+}
+
+type sdnControllerStatusDataSource struct {
+	client proxmox.Client
+}
+
+// Metadata returns the data source type name.
+func (d *sdnControllerStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_controller_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *sdnControllerStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the state of the BGP EVPN peer sessions of a node, established by the EVPN " +
+			"SDN controller. Proxmox has no API for it, so the FRR status is read over SSH with `vtysh`, " +
+			"which requires the SSH user to be root or to have sudo permissions.",
+		Attributes: map[string]schema.Attribute{
+			"node_name": schema.StringAttribute{
+				Description: "Name of the node to read the peer sessions from.",
+				Required:    true,
+			},
+			"peers": schema.ListNestedAttribute{
+				Description: "BGP EVPN peer sessions of the node, sorted by peer.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"peer": schema.StringAttribute{
+							Description: "Address or interface of the peer.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of the BGP session, e.g. `Established`, `Active` or `Idle`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sdnControllerStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource but got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the BGP EVPN peer sessions of the node.
+func (d *sdnControllerStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state sdnControllerStatusDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeName := state.NodeName.ValueString()
+
+	out, err := d.client.SSH().ExecuteNodeCommands(ctx, nodeName, []string{evpnSummaryCommand})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SDN Controller Status",
+			fmt.Sprintf("Failed to read the BGP EVPN status of node %s: %s", nodeName, err),
+		)
+		return
+	}
+
+	peers, err := parseEvpnPeers(out)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SDN Controller Status",
+			fmt.Sprintf("Failed to parse the BGP EVPN status of node %s: %s", nodeName, err),
+		)
+		return
+	}
+
+	state.Peers = peers
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// evpnSummary is the part of the `show bgp l2vpn evpn summary json` output of FRR describing the peers.
+// Depending on the FRR version, the summary is either top-level or nested under the address family.
+type evpnSummary struct {
+	Peers     map[string]evpnPeer `json:"peers"`
+	L2VpnEvpn *struct {
+		Peers map[string]evpnPeer `json:"peers"`
+	} `json:"l2VpnEvpn"`
+}
+
+type evpnPeer struct {
+	State string `json:"state"`
+}
+
+// parseEvpnPeers parses the BGP EVPN peer sessions from the FRR summary, sorted by peer.
+// FRR prints an empty object when BGP isn't running on the node.
+func parseEvpnPeers(out []byte) ([]sdnControllerPeerModel, error) {
+	trimmed := strings.TrimSpace(string(out))
+	if trimmed == "" {
+		return nil, errors.New("empty output, is FRR installed on the node?")
+	}
+
+	summary := &evpnSummary{}
+	if err := json.Unmarshal([]byte(trimmed), summary); err != nil {
+		return nil, fmt.Errorf("unexpected output %q: %w", trimmed, err)
+	}
+
+	peers := summary.Peers
+	if peers == nil && summary.L2VpnEvpn != nil {
+		peers = summary.L2VpnEvpn.Peers
+	}
+
+	names := make([]string, 0, len(peers))
+	for name := range peers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	result := make([]sdnControllerPeerModel, 0, len(names))
+	for _, name := range names {
+		result = append(result, sdnControllerPeerModel{
+			Peer:  types.StringValue(name),
+			State: types.StringValue(peers[name].State),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvpnPeers(t *testing.T) {
+	t.Parallel()
+
+	expected := []sdnControllerPeerModel{
+		{Peer: types.StringValue("10.0.0.2"), State: types.StringValue("Established")},
+		{Peer: types.StringValue("10.0.0.3"), State: types.StringValue("Idle")},
+	}
+
+	tests := []struct {
+		name     string
+		output   string
+		expected []sdnControllerPeerModel
+		err      bool
+	}{
+		{
+			"top-level summary",
+			`{"routerId":"10.0.0.1","as":65000,"peers":{` +
+				`"10.0.0.3":{"remoteAs":65000,"state":"Idle"},` +
+				`"10.0.0.2":{"remoteAs":65000,"state":"Established"}}}`,
+			expected,
+			false,
+		},
+		{
+			"summary nested under the address family",
+			`{"l2VpnEvpn":{"routerId":"10.0.0.1","as":65000,"peers":{` +
+				`"10.0.0.2":{"state":"Established"},"10.0.0.3":{"state":"Idle"}}}}`,
+			expected,
+			false,
+		},
+		{"BGP not running", "{}\n", []sdnControllerPeerModel{}, false},
+		{"no output", "", nil, true},
+		{"not JSON", "% Unknown command", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			peers, err := parseEvpnPeers([]byte(tt.output))
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, peers)
+		})
+	}
+}
+
+// recordingSSHClient records the commands executed on the nodes and returns a fixed output.
+type recordingSSHClient struct {
+	ssh.Client
+
+	nodeName string
+	commands []string
+	output   string
+}
+
+func (c *recordingSSHClient) ExecuteNodeCommands(_ context.Context, nodeName string, commands []string) ([]byte, error) {
+	c.nodeName = nodeName
+	c.commands = commands
+
+	return []byte(c.output), nil
+}
+
+func TestControllerStatusRead(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	sshClient := &recordingSSHClient{output: `{"peers":{"10.0.0.2":{"state":"Established"}}}`}
+	d := &sdnControllerStatusDataSource{client: proxmox.NewClient(nil, sshClient, "")}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	config := tfsdk.Config{Schema: schemaResp.Schema}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &sdnControllerStatusDataSourceModel{
+		NodeName: types.StringValue("pve1"),
+		Peers:    []sdnControllerPeerModel{},
+	}).HasError())
+
+	config.Raw = state.Raw
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	// vtysh must receive the whole show command as the single argument of -c.
+	require.Equal(t, "pve1", sshClient.nodeName)
+	require.Equal(t, []string{
+		`if command -v sudo >/dev/null 2>&1 && sudo -n true 2>/dev/null; then ` +
+			`sudo -n vtysh -c "show bgp l2vpn evpn summary json"; ` +
+			`else vtysh -c "show bgp l2vpn evpn summary json"; fi`,
+	}, sshClient.commands)
+
+	var model sdnControllerStatusDataSourceModel
+
+	require.False(t, resp.State.Get(ctx, &model).HasError())
+	require.Equal(t, []sdnControllerPeerModel{
+		{Peer: types.StringValue("10.0.0.2"), State: types.StringValue("Established")},
+	}, model.Peers)
+}
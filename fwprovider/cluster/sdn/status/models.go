@@ -0,0 +1,25 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_status
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sdnStatusDataSourceModel struct {
+	ConfigDigest types.String `tfsdk:"config_digest"`
+}
+
+type sdnControllerStatusDataSourceModel struct {
+	NodeName types.String             `tfsdk:"node_name"`
+	Peers    []sdnControllerPeerModel `tfsdk:"peers"`
+}
+
+type sdnControllerPeerModel struct {
+	Peer  types.String `tfsdk:"peer"`
+	State types.String `tfsdk:"state"`
+}
@@ -0,0 +1,88 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &sdnStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &sdnStatusDataSource{}
+)
+
+// NewSdnStatusDataSource creates a new instance of the sdn status data source.
+// It is a helper function to simplify the provider implementation.
+func NewSdnStatusDataSource() datasource.DataSource {
+	return &sdnStatusDataSource{}
+}
+
+type sdnStatusDataSource struct {
+	client proxmox.Client
+}
+
+// Metadata returns the data source type name.
+func (d *sdnStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *sdnStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the status of the Proxmox SDN configuration.",
+		Attributes: map[string]schema.Attribute{
+			"config_digest": schema.StringAttribute{
+				Description: "Digest of the whole SDN configuration. It changes whenever any SDN object is modified.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *sdnStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource but got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = cfg.Client
+}
+
+// Read fetches the current SDN status from the Proxmox API.
+func (d *sdnStatusDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	digest, err := d.client.Cluster().SDN().ConfigDigest(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading SDN Status",
+			fmt.Sprintf("Failed to read SDN config digest: %s", err),
+		)
+		return
+	}
+
+	state := sdnStatusDataSourceModel{
+		ConfigDigest: types.StringValue(digest),
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
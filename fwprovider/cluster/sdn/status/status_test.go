@@ -0,0 +1,85 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_status
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestSdnStatusRead(t *testing.T) {
+	t.Parallel()
+
+	sections := map[string]any{
+		"controllers": []map[string]any{},
+		"dns":         []map[string]any{},
+		"ipams":       []map[string]any{{"ipam": "pve", "digest": "ipams1"}},
+		"zones":       []map[string]any{{"zone": "zone1", "digest": "zones1"}},
+		"vnets":       []map[string]any{},
+	}
+
+	tests := []struct {
+		name     string
+		sections map[string]any
+		err      bool
+	}{
+		{"digest", sections, false},
+		{"unreadable configuration", map[string]any{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			client := proxmox.NewClient(sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, ok := tt.sections[strings.Trim(strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/"), "/")]
+				if !ok {
+					http.Error(w, "", http.StatusInternalServerError)
+					return
+				}
+
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+			})), nil, "")
+
+			d := &sdnStatusDataSource{client: client}
+
+			schemaResp := &datasource.SchemaResponse{}
+			d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			d.Read(ctx, datasource.ReadRequest{}, resp)
+
+			if tt.err {
+				require.True(t, resp.Diagnostics.HasError())
+				require.Equal(t, "Error Reading SDN Status", resp.Diagnostics.Errors()[0].Summary())
+
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			expected, err := client.Cluster().SDN().ConfigDigest(ctx)
+			require.NoError(t, err)
+
+			var state sdnStatusDataSourceModel
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			require.Equal(t, expected, state.ConfigDigest.ValueString())
+		})
+	}
+}
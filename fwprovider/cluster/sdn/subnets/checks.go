@@ -0,0 +1,232 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// isSet reports whether a planned string value is known and not null.
+func isSet(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// vnetZone returns the zone of the VNet of the subnet, or nil if it can't be determined, e.g. because the VNet
+// is created in the same plan. Failures to read an existing zone are reported as warnings with the summary.
+func (r *sdnSubnetResource) vnetZone(ctx context.Context, model *sdnSubnetResourceModel, summary string, diags *diag.Diagnostics) *zones.SdnZoneBody {
+	vnetName := model.VNet.ValueString()
+
+	vnet, err := r.client.Cluster().SDN().VNets().Get(ctx, vnetName)
+	if err != nil || vnet.Zone == nil {
+		return nil
+	}
+
+	zone, err := r.client.Cluster().SDN().Zones().Get(ctx, *vnet.Zone)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags, summary, fmt.Sprintf("Failed to read SDN zone %s of VNet %s", *vnet.Zone, vnetName), err)
+		return nil
+	}
+
+	return zone
+}
+
+// checkDNSZonePrefix warns when the subnet sets a DNS zone prefix, but the zone of its VNet has no DNS zone
+// to prefix, so the hostnames of the subnet are never registered under the prefix.
+func (r *sdnSubnetResource) checkDNSZonePrefix(ctx context.Context, model *sdnSubnetResourceModel, diags *diag.Diagnostics) {
+	zone := r.vnetZone(ctx, model, "Unable to Validate SDN Subnet DNS", diags)
+	if zone == nil {
+		return
+	}
+
+	if zone.Dnszone == nil || *zone.Dnszone == "" {
+		sdn.AddWarning(diags, r.strict,
+			"SDN Subnet DNS Zone Prefix Not Used",
+			fmt.Sprintf("SDN subnet %s sets the DNS zone prefix %s, but the zone %s of VNet %s has no `dnszone`, "+
+				"so the prefix is not used.", model.CIDR.ValueString(), model.DNSZonePrefix.ValueString(), zone.Name,
+				model.VNet.ValueString()),
+		)
+	}
+}
+
+// checkDHCPDNSServer warns when the subnet sets a DHCP DNS server, but the zone of its VNet has no DHCP server
+// to hand it out.
+func (r *sdnSubnetResource) checkDHCPDNSServer(ctx context.Context, model *sdnSubnetResourceModel, diags *diag.Diagnostics) {
+	zone := r.vnetZone(ctx, model, "Unable to Validate SDN Subnet DHCP", diags)
+	if zone == nil {
+		return
+	}
+
+	if zone.Dhcp == nil || *zone.Dhcp == "" {
+		sdn.AddWarning(diags, r.strict,
+			"SDN Subnet DHCP DNS Server Not Used",
+			fmt.Sprintf("SDN subnet %s sets the DHCP DNS server %s, but the zone %s of VNet %s has no automatic "+
+				"DHCP, so the DNS server is not handed out.", model.CIDR.ValueString(), model.DHCPDNSServer.ValueString(),
+				zone.Name, model.VNet.ValueString()),
+		)
+	}
+}
+
+// checkDHCPRange warns when the subnet has DHCP ranges, but the zone of its VNet has no DHCP server to lease
+// them. The zone isn't required to have DHCP enabled yet, as it may be enabled by the same plan.
+func (r *sdnSubnetResource) checkDHCPRange(ctx context.Context, model *sdnSubnetResourceModel, diags *diag.Diagnostics) {
+	zone := r.vnetZone(ctx, model, "Unable to Validate SDN Subnet DHCP", diags)
+	if zone == nil {
+		return
+	}
+
+	if zone.Dhcp == nil || *zone.Dhcp == "" {
+		sdn.AddWarning(diags, r.strict,
+			"SDN Subnet DHCP Range Not Used",
+			fmt.Sprintf("SDN subnet %s has DHCP ranges, but the zone %s of VNet %s has no automatic DHCP, "+
+				"so no address is leased. Enable DHCP with `simple.dhcp` on the zone.",
+				model.CIDR.ValueString(), zone.Name, model.VNet.ValueString()),
+		)
+	}
+}
+
+// addCreateError reports a failed creation of the subnet. Proxmox registers the subnet with the IPAM of
+// the zone of its VNet, e.g. NetBox or phpIPAM, while creating it, so errors of the external IPAM are
+// reported by Proxmox as a failed creation. These are reported separately, naming the IPAM involved.
+func (r *sdnSubnetResource) addCreateError(ctx context.Context, model *sdnSubnetResourceModel, err error, diags *diag.Diagnostics) {
+	if !strings.Contains(strings.ToLower(err.Error()), "ipam") {
+		sdn.AddAPIError(diags,
+			"Error Creating SDN Subnet",
+			fmt.Sprintf("Failed to create SDN subnet %s in VNet %s", model.CIDR.ValueString(), model.VNet.ValueString()),
+			err, attributePath,
+		)
+		return
+	}
+
+	ipam := "the IPAM of its zone"
+	if description := r.describeIPAM(ctx, model.VNet.ValueString()); description != "" {
+		ipam = description
+	}
+
+	diags.AddError(
+		"Error Registering SDN Subnet in IPAM",
+		fmt.Sprintf("Failed to create SDN subnet %s in VNet %s, as it couldn't be registered in %s: %s. "+
+			"Check that the IPAM is reachable from the Proxmox nodes and that its credentials allow managing prefixes.",
+			model.CIDR.ValueString(), model.VNet.ValueString(), ipam, err),
+	)
+}
+
+// describeIPAM describes the IPAM of the zone of the VNet, e.g. "netbox IPAM nb of zone zone1",
+// or returns an empty string if it can't be determined.
+func (r *sdnSubnetResource) describeIPAM(ctx context.Context, vnetName string) string {
+	vnet, err := r.client.Cluster().SDN().VNets().Get(ctx, vnetName)
+	if err != nil || vnet.Zone == nil {
+		return ""
+	}
+
+	zone, err := r.client.Cluster().SDN().Zones().Get(ctx, *vnet.Zone)
+	if err != nil || zone.Ipam == nil || *zone.Ipam == "" {
+		return ""
+	}
+
+	ipamList, err := r.client.Cluster().SDN().IPAMs().List(ctx)
+	if err != nil {
+		return ""
+	}
+
+	for _, ipam := range ipamList {
+		if ipam.Name == *zone.Ipam && ipam.Type != nil {
+			return fmt.Sprintf("%s IPAM %s of zone %s", *ipam.Type, ipam.Name, zone.Name)
+		}
+	}
+
+	return fmt.Sprintf("IPAM %s of zone %s", *zone.Ipam, zone.Name)
+}
+
+// checkOverlap warns when the CIDR of the planned subnet overlaps with an existing SDN subnet of a VNet of
+// the same zone, skipping the subnet being replaced. Subnets of different zones may overlap, as the zones
+// are isolated from each other. Only the existing subnets are checked, not the other subnets of the plan,
+// nor the subnets of a VNet created in the same plan, whose zone isn't known yet.
+func (r *sdnSubnetResource) checkOverlap(ctx context.Context, model *sdnSubnetResourceModel, replacedID string, diags *diag.Diagnostics) {
+	cidr, err := netip.ParsePrefix(model.CIDR.ValueString())
+	if err != nil || !isSet(model.VNet) {
+		// An invalid CIDR is already reported by the CIDR validator.
+		return
+	}
+
+	vnetList, err := r.client.Cluster().SDN().VNets().ListAll(ctx)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Subnet CIDR",
+			"Failed to list SDN VNets",
+			err,
+		)
+		return
+	}
+
+	var zone string
+
+	for _, vnet := range vnetList {
+		if vnet.Name == model.VNet.ValueString() && vnet.Zone != nil {
+			zone = *vnet.Zone
+		}
+	}
+
+	if zone == "" {
+		return
+	}
+
+	for _, vnet := range vnetList {
+		if vnet.Zone == nil || *vnet.Zone != zone {
+			continue
+		}
+
+		subnetList, err := r.client.Cluster().SDN().Subnets().List(ctx, vnet.Name)
+		if err != nil {
+			sdn.AddBestEffortWarning(diags,
+				"Unable to Validate SDN Subnet CIDR",
+				fmt.Sprintf("Failed to list SDN subnets of VNet %s", vnet.Name),
+				err,
+			)
+			return
+		}
+
+		if subnet := findOverlappingSubnet(cidr, subnetList, replacedID); subnet != nil {
+			sdn.AddWarning(diags, r.strict,
+				"Overlapping SDN Subnets",
+				fmt.Sprintf("SDN subnet %s of VNet %s overlaps with the existing SDN subnet %s of VNet %s in the same "+
+					"zone %s, which can't be routed reliably", cidr, model.VNet.ValueString(), subnet.CIDR, vnet.Name, zone),
+			)
+			return
+		}
+	}
+}
+
+// findOverlappingSubnet returns the first subnet of the list overlapping with the CIDR, ignoring the
+// subnet with the given identifier and subnets with an invalid CIDR.
+func findOverlappingSubnet(cidr netip.Prefix, list []*subnets.SdnSubnetBody, ignoredID string) *subnets.SdnSubnetBody {
+	for _, subnet := range list {
+		if subnet.Subnet == ignoredID {
+			continue
+		}
+
+		existing, err := netip.ParsePrefix(subnet.CIDR)
+		if err != nil {
+			continue
+		}
+
+		if existing.Overlaps(cidr) {
+			return subnet
+		}
+	}
+
+	return nil
+}
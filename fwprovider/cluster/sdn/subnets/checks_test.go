@@ -0,0 +1,285 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+)
+
+// newTestClient creates a client connected to a test server using the given handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) proxmox.Client {
+	t.Helper()
+
+	return proxmox.NewClient(sdntest.NewAPIClient(t, handler), nil, "")
+}
+
+func TestFindOverlappingSubnet(t *testing.T) {
+	t.Parallel()
+
+	list := []*subnets.SdnSubnetBody{
+		{Subnet: "zone1-10.0.0.0-24", CIDR: "10.0.0.0/24"},
+		{Subnet: "zone1-fd00::-64", CIDR: "fd00::/64"},
+		{Subnet: "zone1-invalid", CIDR: ""},
+	}
+
+	tests := []struct {
+		name      string
+		cidr      string
+		ignoredID string
+		expected  string
+	}{
+		{"disjoint", "10.0.1.0/24", "", ""},
+		{"same network", "10.0.0.0/24", "", "zone1-10.0.0.0-24"},
+		{"supernet", "10.0.0.0/16", "", "zone1-10.0.0.0-24"},
+		{"subnet", "10.0.0.128/25", "", "zone1-10.0.0.0-24"},
+		{"replaced subnet", "10.0.0.0/16", "zone1-10.0.0.0-24", ""},
+		{"IPv6", "fd00::/48", "", "zone1-fd00::-64"},
+		{"IPv4 and IPv6", "0.0.0.0/0", "", "zone1-10.0.0.0-24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			subnet := findOverlappingSubnet(netip.MustParsePrefix(tt.cidr), list, tt.ignoredID)
+			if tt.expected == "" {
+				require.Nil(t, subnet)
+				return
+			}
+
+			require.NotNil(t, subnet)
+			require.Equal(t, tt.expected, subnet.Subnet)
+		})
+	}
+}
+
+func TestCheckOverlap(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/vnets/":
+			_, _ = w.Write([]byte(`{"data":[{"vnet":"vnet1","zone":"zone1"},{"vnet":"vnet2","zone":"zone1"},` +
+				`{"vnet":"vnet3","zone":"zone2"}]}`))
+		case "/api2/json/cluster/sdn/vnets/vnet2/subnets":
+			_, _ = w.Write([]byte(`{"data":[{"subnet":"zone1-10.0.0.0-24","cidr":"10.0.0.0/24"}]}`))
+		case "/api2/json/cluster/sdn/vnets/vnet1/subnets", "/api2/json/cluster/sdn/vnets/vnet3/subnets":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		vnet     string
+		strict   bool
+		warnings int
+		errors   int
+	}{
+		{"same zone", "vnet1", false, 1, 0},
+		{"same zone, strict", "vnet1", true, 0, 1},
+		// The zones are isolated from each other, so their subnets may overlap.
+		{"other zone", "vnet3", false, 0, 0},
+		{"VNet created in the plan", "vnet4", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnSubnetResource{client: client, strict: tt.strict}
+			model := &sdnSubnetResourceModel{
+				VNet: types.StringValue(tt.vnet),
+				CIDR: types.StringValue("10.0.0.0/16"),
+			}
+			diags := diag.Diagnostics{}
+
+			r.checkOverlap(t.Context(), model, "", &diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+			require.Equal(t, tt.errors, diags.ErrorsCount(), "%v", diags)
+		})
+	}
+}
+
+func TestCheckDNSZonePrefix(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/vnets/vnet1":
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet1","zone":"dns"}}`))
+		case "/api2/json/cluster/sdn/vnets/vnet2":
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet2","zone":"nodns"}}`))
+		case "/api2/json/cluster/sdn/zones/dns":
+			_, _ = w.Write([]byte(`{"data":{"zone":"dns","type":"simple","dns":"powerdns","dnszone":"example.com"}}`))
+		case "/api2/json/cluster/sdn/zones/nodns":
+			_, _ = w.Write([]byte(`{"data":{"zone":"nodns","type":"simple"}}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		vnet     string
+		strict   bool
+		warnings int
+		errors   int
+	}{
+		{"zone with DNS zone", "vnet1", false, 0, 0},
+		{"zone without DNS zone", "vnet2", false, 1, 0},
+		{"zone without DNS zone in strict mode", "vnet2", true, 0, 1},
+		{"VNet not created yet", "vnet3", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnSubnetResource{client: client, strict: tt.strict}
+			diags := diag.Diagnostics{}
+
+			r.checkDNSZonePrefix(t.Context(), &sdnSubnetResourceModel{
+				VNet:          types.StringValue(tt.vnet),
+				CIDR:          types.StringValue("10.0.0.0/24"),
+				DNSZonePrefix: types.StringValue("adm"),
+			}, &diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+			require.Equal(t, tt.errors, diags.ErrorsCount(), "%v", diags)
+		})
+	}
+}
+
+func TestAddCreateError(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/vnets/vnet1":
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet1","zone":"zone1"}}`))
+		case "/api2/json/cluster/sdn/zones/zone1":
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple","ipam":"nb"}}`))
+		case "/api2/json/cluster/sdn/ipams/":
+			_, _ = w.Write([]byte(`{"data":[{"ipam":"pve","type":"pve"},{"ipam":"nb","type":"netbox"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		vnet    string
+		err     error
+		summary string
+		detail  string
+	}{
+		{
+			"other error", "vnet1",
+			errors.New("error creating SDN subnet: subnet already exists"),
+			"Error Creating SDN Subnet", "subnet already exists",
+		},
+		{
+			"IPAM error", "vnet1",
+			errors.New("error creating SDN subnet: error add subnet to ipam: connection refused"),
+			"Error Registering SDN Subnet in IPAM", "netbox IPAM nb of zone zone1",
+		},
+		{
+			"IPAM error of an unknown zone", "vnet2",
+			errors.New("error creating SDN subnet: error add subnet to ipam: connection refused"),
+			"Error Registering SDN Subnet in IPAM", "the IPAM of its zone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnSubnetResource{client: client}
+			diags := diag.Diagnostics{}
+
+			r.addCreateError(t.Context(), &sdnSubnetResourceModel{
+				VNet: types.StringValue(tt.vnet),
+				CIDR: types.StringValue("10.0.0.0/24"),
+			}, tt.err, &diags)
+			require.Equal(t, 1, diags.ErrorsCount())
+			require.Equal(t, tt.summary, diags[0].Summary())
+			require.Contains(t, diags[0].Detail(), tt.detail)
+			require.Contains(t, diags[0].Detail(), tt.err.Error())
+		})
+	}
+}
+
+func TestCheckDHCP(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/vnets/vnet1":
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet1","zone":"dhcp"}}`))
+		case "/api2/json/cluster/sdn/vnets/vnet2":
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet2","zone":"nodhcp"}}`))
+		case "/api2/json/cluster/sdn/zones/dhcp":
+			_, _ = w.Write([]byte(`{"data":{"zone":"dhcp","type":"simple","dhcp":"dnsmasq"}}`))
+		case "/api2/json/cluster/sdn/zones/nodhcp":
+			_, _ = w.Write([]byte(`{"data":{"zone":"nodhcp","type":"simple"}}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		vnet     string
+		warnings int
+	}{
+		{"zone with DHCP", "vnet1", 0},
+		{"zone without DHCP", "vnet2", 1},
+		{"VNet not created yet", "vnet3", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnSubnetResource{client: client}
+			diags := diag.Diagnostics{}
+
+			r.checkDHCPDNSServer(t.Context(), &sdnSubnetResourceModel{
+				VNet:          types.StringValue(tt.vnet),
+				CIDR:          types.StringValue("10.0.0.0/24"),
+				DHCPDNSServer: types.StringValue("10.0.0.53"),
+			}, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+
+			diags = diag.Diagnostics{}
+
+			r.checkDHCPRange(t.Context(), &sdnSubnetResourceModel{
+				VNet: types.StringValue(tt.vnet),
+				CIDR: types.StringValue("10.0.0.0/24"),
+				DHCPRange: []sdnSubnetDHCPRangeModel{{
+					StartAddress: types.StringValue("10.0.0.100"),
+					EndAddress:   types.StringValue("10.0.0.200"),
+				}},
+			}, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+		})
+	}
+}
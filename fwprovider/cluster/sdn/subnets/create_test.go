@@ -0,0 +1,69 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFindsSubnetByPrefix(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	// Proxmox lists the IPv6 network in its canonical form, not as written in the configuration.
+	const subnet = `{"subnet":"zone1-fd00::-64","cidr":"fd00::/64","vnet":"vnet1","type":"subnet"}`
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api2/json/cluster/sdn/vnets/vnet1/subnets":
+			_, _ = w.Write([]byte(`{"data":null}`))
+		case r.URL.Path == "/api2/json/cluster/sdn/vnets/vnet1/subnets":
+			_, _ = w.Write([]byte(`{"data":[` + subnet + `]}`))
+		case r.URL.Path == "/api2/json/cluster/sdn/vnets/vnet1/subnets/zone1-fd00::-64":
+			_, _ = w.Write([]byte(`{"data":` + subnet + `}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	r := &sdnSubnetResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &sdnSubnetResourceModel{
+		ID:            types.StringUnknown(),
+		VNet:          types.StringValue("vnet1"),
+		CIDR:          types.StringValue("fd00:0::/64"),
+		Gateway:       types.StringNull(),
+		SNAT:          types.BoolNull(),
+		DNSZonePrefix: types.StringNull(),
+		DHCPDNSServer: types.StringNull(),
+	}).HasError())
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var id types.String
+
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	require.Equal(t, "zone1-fd00::-64", id.ValueString())
+}
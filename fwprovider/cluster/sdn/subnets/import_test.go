@@ -0,0 +1,72 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportState(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api2/json/cluster/sdn/vnets/vnet1/subnets" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":[{"subnet":"zone1-10.0.0.0-24","cidr":"10.0.0.0/24","gateway":"10.0.0.1"}]}`))
+	})
+
+	r := &sdnSubnetResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tests := []struct {
+		name  string
+		id    string
+		found bool
+	}{
+		{"by identifier", "vnet1/zone1-10.0.0.0-24", true},
+		{"by CIDR", "vnet1/10.0.0.0/24", true},
+		{"unknown subnet", "vnet1/10.0.1.0/24", false},
+		{"unknown VNet", "vnet2/10.0.0.0/24", false},
+		{"missing VNet", "zone1-10.0.0.0-24", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			require.Equal(t, !tt.found, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if !tt.found {
+				return
+			}
+
+			var model sdnSubnetResourceModel
+			require.False(t, resp.State.Get(ctx, &model).HasError())
+			require.Equal(t, "vnet1", model.VNet.ValueString())
+			require.Equal(t, "zone1-10.0.0.0-24", model.ID.ValueString())
+			require.Equal(t, "10.0.0.0/24", model.CIDR.ValueString())
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type sdnSubnetResourceModel struct {
+	ID              types.String              `tfsdk:"id"`
+	VNet            types.String              `tfsdk:"vnet"`
+	CIDR            types.String              `tfsdk:"cidr"`
+	Gateway         types.String              `tfsdk:"gateway"`
+	AssignedGateway types.String              `tfsdk:"assigned_gateway"`
+	SNAT            types.Bool                `tfsdk:"snat"`
+	DNSZonePrefix   types.String              `tfsdk:"dnszoneprefix"`
+	DHCPDNSServer   types.String              `tfsdk:"dhcp_dns_server"`
+	DHCPRange       []sdnSubnetDHCPRangeModel `tfsdk:"dhcp_range"`
+}
+
+type sdnSubnetDHCPRangeModel struct {
+	StartAddress types.String `tfsdk:"start_address"`
+	EndAddress   types.String `tfsdk:"end_address"`
+}
+
+// subnetParams maps the parameters of the Proxmox API to the attributes of the subnet.
+var subnetParams = map[string]string{
+	"subnet":          "cidr",
+	"vnet":            "vnet",
+	"gateway":         "gateway",
+	"snat":            "snat",
+	"dnszoneprefix":   "dnszoneprefix",
+	"dhcp-dns-server": "dhcp_dns_server",
+	"dhcp-range":      "dhcp_range",
+}
+
+// attributePath returns the path of the attribute set from a parameter of the Proxmox API.
+func attributePath(param string) (path.Path, bool) {
+	if attribute, ok := subnetParams[param]; ok {
+		return path.Root(attribute), true
+	}
+
+	return path.Empty(), false
+}
+
+// RemoveAllAttributes resets all attributes except the identifying ones.
+func (m *sdnSubnetResourceModel) RemoveAllAttributes() {
+	*m = sdnSubnetResourceModel{
+		ID:   m.ID,
+		VNet: m.VNet,
+		CIDR: m.CIDR,
+	}
+}
+
+// exportToSdnSubnetBody converts the resource model to a SDN subnet body for API requests.
+func (m *sdnSubnetResourceModel) exportToSdnSubnetBody() *subnets.SdnSubnetBody {
+	subnetType := "subnet"
+
+	var dhcpRanges subnets.SdnSubnetDHCPRanges
+
+	for _, dhcpRange := range m.DHCPRange {
+		dhcpRanges = append(dhcpRanges, subnets.SdnSubnetDHCPRange{
+			StartAddress: dhcpRange.StartAddress.ValueString(),
+			EndAddress:   dhcpRange.EndAddress.ValueString(),
+		})
+	}
+
+	return &subnets.SdnSubnetBody{
+		Subnet:        m.CIDR.ValueString(),
+		Type:          &subnetType,
+		Gateway:       m.Gateway.ValueStringPointer(),
+		SNAT:          proxmoxtypes.CustomBoolPtr(m.SNAT.ValueBoolPointer()),
+		DNSZonePrefix: m.DNSZonePrefix.ValueStringPointer(),
+		DHCPDNSServer: m.DHCPDNSServer.ValueStringPointer(),
+		DHCPRange:     dhcpRanges,
+	}
+}
+
+// importFromSdnSubnetBody populates the resource model from a SDN subnet body.
+func (m *sdnSubnetResourceModel) importFromSdnSubnetBody(body *subnets.SdnSubnetBody) {
+	m.ID = types.StringValue(body.Subnet)
+	m.CIDR = types.StringValue(body.CIDR)
+	m.Gateway = types.StringPointerValue(body.Gateway)
+	m.AssignedGateway = types.StringPointerValue(body.Gateway)
+	m.SNAT = types.BoolPointerValue(body.SNAT.PointerBool())
+	m.DNSZonePrefix = types.StringPointerValue(body.DNSZonePrefix)
+	m.DHCPDNSServer = types.StringPointerValue(body.DHCPDNSServer)
+	m.DHCPRange = nil
+
+	for _, dhcpRange := range body.DHCPRange {
+		m.DHCPRange = append(m.DHCPRange, sdnSubnetDHCPRangeModel{
+			StartAddress: types.StringValue(dhcpRange.StartAddress),
+			EndAddress:   types.StringValue(dhcpRange.EndAddress),
+		})
+	}
+
+	if body.VNet != nil {
+		m.VNet = types.StringValue(*body.VNet)
+	}
+}
+
+func (m *sdnSubnetResourceModel) exportToUpdateBody() *subnets.SdnSubnetBody {
+	body := m.exportToSdnSubnetBody()
+
+	// Add to delete_tab any fields that are unset in the request body.
+	var deleteTab []string
+
+	if body.Gateway == nil {
+		deleteTab = append(deleteTab, "gateway")
+	}
+	if body.SNAT == nil {
+		deleteTab = append(deleteTab, "snat")
+	}
+	if body.DNSZonePrefix == nil {
+		deleteTab = append(deleteTab, "dnszoneprefix")
+	}
+	if body.DHCPDNSServer == nil {
+		deleteTab = append(deleteTab, "dhcp-dns-server")
+	}
+	if len(body.DHCPRange) == 0 {
+		deleteTab = append(deleteTab, "dhcp-range")
+	}
+
+	if len(deleteTab) > 0 {
+		toDelete := strings.Join(deleteTab, ",")
+		body.Delete = &toDelete
+	}
+
+	// Update requests identify the subnet by the path and don't accept the "type" field.
+	body.Subnet = ""
+	body.Type = nil
+
+	return body
+}
@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestSdnSubnetGateway(t *testing.T) {
+	t.Parallel()
+
+	model := func(gateway types.String) *sdnSubnetResourceModel {
+		return &sdnSubnetResourceModel{
+			VNet:    types.StringValue("vnet1"),
+			CIDR:    types.StringValue("10.0.0.0/24"),
+			Gateway: gateway,
+		}
+	}
+
+	require.Equal(t, "10.0.0.1", *model(types.StringValue("10.0.0.1")).exportToSdnSubnetBody().Gateway)
+	require.NotContains(t, ptr.Or(model(types.StringValue("10.0.0.1")).exportToUpdateBody().Delete, ""), "gateway")
+
+	// Removing the gateway from the configuration deletes it.
+	require.Nil(t, model(types.StringNull()).exportToSdnSubnetBody().Gateway)
+	require.Contains(t, *model(types.StringNull()).exportToUpdateBody().Delete, "gateway")
+
+	m := model(types.StringNull())
+	m.importFromSdnSubnetBody(&subnets.SdnSubnetBody{
+		Subnet:  "zone1-10.0.0.0-24",
+		CIDR:    "10.0.0.0/24",
+		Gateway: ptr.Ptr("10.0.0.254"),
+	})
+	require.Equal(t, types.StringValue("10.0.0.254"), m.Gateway)
+	require.Equal(t, types.StringValue("10.0.0.254"), m.AssignedGateway)
+}
+
+func TestSdnSubnetDHCPRange(t *testing.T) {
+	t.Parallel()
+
+	m := &sdnSubnetResourceModel{
+		VNet: types.StringValue("vnet1"),
+		CIDR: types.StringValue("10.0.0.0/24"),
+		DHCPRange: []sdnSubnetDHCPRangeModel{{
+			StartAddress: types.StringValue("10.0.0.100"),
+			EndAddress:   types.StringValue("10.0.0.200"),
+		}},
+	}
+
+	expected := subnets.SdnSubnetDHCPRanges{{StartAddress: "10.0.0.100", EndAddress: "10.0.0.200"}}
+	require.Equal(t, expected, m.exportToSdnSubnetBody().DHCPRange)
+	require.NotContains(t, ptr.Or(m.exportToUpdateBody().Delete, ""), "dhcp-range")
+
+	// Removing the ranges disables DHCP for the subnet.
+	m.DHCPRange = nil
+	require.Contains(t, *m.exportToUpdateBody().Delete, "dhcp-range")
+
+	m.importFromSdnSubnetBody(&subnets.SdnSubnetBody{Subnet: "zone1-10.0.0.0-24", DHCPRange: expected})
+	require.Len(t, m.DHCPRange, 1)
+	require.Equal(t, types.StringValue("10.0.0.200"), m.DHCPRange[0].EndAddress)
+
+	m.importFromSdnSubnetBody(&subnets.SdnSubnetBody{Subnet: "zone1-10.0.0.0-24"})
+	require.Nil(t, m.DHCPRange)
+}
@@ -0,0 +1,93 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		found   bool
+		errored bool
+	}{
+		{"not found status", http.StatusNotFound, false, false},
+		{"other error", http.StatusForbidden, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnSubnetResource{client: newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "", tt.status)
+			})}
+
+			model := sdnSubnetResourceModel{
+				ID:      types.StringValue("zone1-10.0.0.0-24"),
+				VNet:    types.StringValue("vnet1"),
+				CIDR:    types.StringValue("10.0.0.0/24"),
+				Gateway: types.StringValue("10.0.0.1"),
+			}
+			diags := diag.Diagnostics{}
+
+			require.Equal(t, tt.found, r.read(t.Context(), &model, &diags))
+			require.Equal(t, tt.errored, diags.HasError(), "%v", diags)
+
+			if !tt.found {
+				require.Equal(t, 1, diags.WarningsCount())
+				require.True(t, model.Gateway.IsNull(), "the subnet must be removed from the state")
+			}
+		})
+	}
+}
+
+func TestReadAssignedGateway(t *testing.T) {
+	t.Parallel()
+
+	r := &sdnSubnetResource{client: newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"subnet":"zone1-10.0.0.0-24","cidr":"10.0.0.0/24","gateway":"10.0.0.254"}}`))
+	})}
+
+	tests := []struct {
+		name     string
+		gateway  types.String
+		expected types.String
+	}{
+		// The gateway assigned by the IPAM isn't reported as a change of the unset gateway.
+		{"unset gateway", types.StringNull(), types.StringNull()},
+		{"configured gateway", types.StringValue("10.0.0.1"), types.StringValue("10.0.0.254")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := sdnSubnetResourceModel{
+				ID:      types.StringValue("zone1-10.0.0.0-24"),
+				VNet:    types.StringValue("vnet1"),
+				CIDR:    types.StringValue("10.0.0.0/24"),
+				Gateway: tt.gateway,
+			}
+			diags := diag.Diagnostics{}
+
+			require.True(t, r.read(t.Context(), &model, &diags))
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.expected, model.Gateway)
+			require.Equal(t, types.StringValue("10.0.0.254"), model.AssignedGateway)
+		})
+	}
+}
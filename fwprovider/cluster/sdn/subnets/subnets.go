@@ -0,0 +1,473 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &sdnSubnetResource{}
+	_ resource.ResourceWithConfigure   = &sdnSubnetResource{}
+	_ resource.ResourceWithModifyPlan  = &sdnSubnetResource{}
+	_ resource.ResourceWithImportState = &sdnSubnetResource{}
+)
+
+// NewSdnSubnetResource creates a new instance of the sdn subnet resource.
+// It is a helper function to simplify the provider implementation.
+func NewSdnSubnetResource() resource.Resource {
+	return &sdnSubnetResource{}
+}
+
+type sdnSubnetResource struct {
+	client        proxmox.Client
+	strict        bool
+	quietNotFound bool
+	apiValidation bool
+}
+
+// Metadata returns the resource type name.
+func (r *sdnSubnetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_subnet"
+}
+
+// Schema defines the schema for the resource.
+func (r *sdnSubnetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Proxmox SDN subnet. Subnets are registered in the IPAM of the zone " +
+			"their VNet belongs to, there is no per-subnet IPAM setting.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ResourceID("Identifier of the SDN subnet, in the `<zone>-<network>-<mask>` format."),
+			"vnet": schema.StringAttribute{
+				Description: "Name of the VNet the SDN subnet belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Description: "Network of the SDN subnet in the CIDR notation.",
+				Required:    true,
+				Validators: []validator.String{
+					cidrValidator(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Description: "Gateway IP address of the SDN subnet. It is registered in the IPAM of the zone.",
+				Optional:    true,
+				Validators: []validator.String{
+					addressValidator(),
+				},
+			},
+			"assigned_gateway": schema.StringAttribute{
+				Description: "Gateway IP address of the SDN subnet in Proxmox, either the configured `gateway` " +
+					"or the one assigned outside of Terraform, e.g. by the IPAM, when `gateway` is not set.",
+				Computed: true,
+			},
+			"snat": schema.BoolAttribute{
+				Description: "Enable source NAT for the traffic leaving the SDN subnet.",
+				Optional:    true,
+			},
+			"dnszoneprefix": schema.StringAttribute{
+				Description: "DNS zone prefix, e.g. `adm` for `<hostname>.adm.<dnszone>`. The prefix " +
+					"is only used when the zone of the VNet has a `dnszone`. Reverse DNS records of the " +
+					"subnet are registered in the `reversedns` server of the zone, Proxmox has no " +
+					"per-subnet reverse DNS setting.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"dhcp_dns_server": schema.StringAttribute{
+				Description: "IP address of the DNS server handed out by the DHCP server of the zone to the " +
+					"guests of the SDN subnet. Only used when the zone of the VNet has automatic DHCP enabled, " +
+					"i.e. `dhcp = \"dnsmasq\"`. The DNS server is the only DHCP option supported by Proxmox, " +
+					"and it's set per subnet, not per zone.",
+				Optional: true,
+				Validators: []validator.String{
+					addressValidator(),
+				},
+			},
+			"dhcp_range": schema.ListNestedAttribute{
+				Description: "Ranges of addresses leased by the DHCP server of the zone to the guests of the " +
+					"SDN subnet. DHCP is enabled for the subnet when it has at least one range, which requires " +
+					"the zone of the VNet to have automatic DHCP enabled, i.e. `dhcp = \"dnsmasq\"`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start_address": schema.StringAttribute{
+							Description: "First address of the range.",
+							Required:    true,
+							Validators: []validator.String{
+								addressValidator(),
+							},
+						},
+						"end_address": schema.StringAttribute{
+							Description: "Last address of the range.",
+							Required:    true,
+							Validators: []validator.String{
+								addressValidator(),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *sdnSubnetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource but got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = cfg.Client
+	r.strict = cfg.SDNStrict
+	r.quietNotFound = cfg.SDNQuietNotFound
+	r.apiValidation = cfg.SDNAPIValidation
+}
+
+// ModifyPlan checks the subnet against the existing SDN configuration, when the API validation is enabled:
+// that a new subnet doesn't overlap with the existing ones of its zone, and that its DNS and DHCP settings
+// are used by its zone.
+func (r *sdnSubnetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !r.apiValidation || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan sdnSubnetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isSet(plan.DNSZonePrefix) && isSet(plan.VNet) {
+		r.checkDNSZonePrefix(ctx, &plan, &resp.Diagnostics)
+	}
+
+	if isSet(plan.DHCPDNSServer) && isSet(plan.VNet) {
+		r.checkDHCPDNSServer(ctx, &plan, &resp.Diagnostics)
+	}
+
+	if len(plan.DHCPRange) > 0 && isSet(plan.VNet) {
+		r.checkDHCPRange(ctx, &plan, &resp.Diagnostics)
+	}
+
+	if plan.CIDR.IsUnknown() {
+		return
+	}
+
+	// The CIDR can't be changed in place, so an existing subnet only needs a check when it's replaced.
+	var replacedID string
+
+	if !req.State.Raw.IsNull() {
+		var state sdnSubnetResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.CIDR.Equal(plan.CIDR) {
+			return
+		}
+
+		replacedID = state.ID.ValueString()
+	}
+
+	r.checkOverlap(ctx, &plan, replacedID, &resp.Diagnostics)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *sdnSubnetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sdnSubnetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.Cluster().SDN().Subnets()
+
+	err := client.Create(ctx, plan.VNet.ValueString(), plan.exportToSdnSubnetBody())
+	if err != nil {
+		r.addCreateError(ctx, &plan, err, &resp.Diagnostics)
+		return
+	}
+
+	// The identifier of the subnet is derived from its zone, so it's only known after the creation.
+	list, err := client.List(ctx, plan.VNet.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing SDN Subnets",
+			fmt.Sprintf("Failed to list SDN subnets of VNet %s: %s", plan.VNet.ValueString(), err),
+		)
+		return
+	}
+
+	// The CIDRs are compared parsed, as Proxmox may write the IPv6 networks differently than the configuration.
+	cidr, err := netip.ParsePrefix(plan.CIDR.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid SDN Subnet CIDR",
+			fmt.Sprintf("Failed to parse the CIDR %s of the created SDN subnet: %s", plan.CIDR.ValueString(), err),
+		)
+		return
+	}
+
+	for _, subnet := range list {
+		if prefix, err := subnet.Prefix(); err == nil && prefix == cidr {
+			plan.ID = types.StringValue(subnet.Subnet)
+			break
+		}
+	}
+
+	if plan.ID.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"SDN Subnet Not Found",
+			fmt.Sprintf("SDN subnet %s was created but is not listed in VNet %s", plan.CIDR.ValueString(), plan.VNet.ValueString()),
+		)
+		return
+	}
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// read fetches the current state of the resource from the Proxmox API and updates the model.
+// It returns false if the subnet doesn't exist.
+func (r *sdnSubnetResource) read(ctx context.Context, model *sdnSubnetResourceModel, diags *diag.Diagnostics) bool {
+	subnet, err := r.client.Cluster().SDN().Subnets().Get(ctx, model.VNet.ValueString(), model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			if !r.quietNotFound {
+				sdn.AddWarning(diags, r.strict,
+					"SDN Subnet Not Found",
+					fmt.Sprintf("SDN subnet %s does not exist, removing it from the state", model.ID.ValueString()),
+				)
+			}
+
+			model.RemoveAllAttributes()
+
+			return false
+		}
+
+		diags.AddError(
+			"Error Reading SDN Subnet",
+			fmt.Sprintf("Failed to read SDN subnet %s: %s", model.ID.ValueString(), err),
+		)
+
+		return true
+	}
+
+	// A gateway assigned outside of Terraform is only reported as the assigned gateway, so that it doesn't
+	// show up as a change of an unset gateway.
+	gatewayUnset := model.Gateway.IsNull()
+
+	model.importFromSdnSubnetBody(subnet)
+
+	if gatewayUnset {
+		model.Gateway = types.StringNull()
+	}
+
+	if subnet.Gateway != nil && subnet.Zone != nil {
+		r.checkZoneIPAM(ctx, *subnet.Zone, model, diags)
+	}
+
+	return true
+}
+
+// checkZoneIPAM warns when the subnet gateway can't be registered because the zone has no IPAM.
+func (r *sdnSubnetResource) checkZoneIPAM(ctx context.Context, zoneName string, model *sdnSubnetResourceModel, diags *diag.Diagnostics) {
+	zone, err := r.client.Cluster().SDN().Zones().Get(ctx, zoneName)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Check SDN Zone IPAM",
+			fmt.Sprintf("Failed to read SDN zone %s of subnet %s", zoneName, model.ID.ValueString()),
+			err,
+		)
+		return
+	}
+
+	if zone.Ipam == nil || *zone.Ipam == "" {
+		sdn.AddWarning(diags, r.strict,
+			"SDN Zone Without IPAM",
+			fmt.Sprintf("SDN subnet %s has a gateway, but its zone %s has no IPAM, "+
+				"so the gateway address is not registered anywhere", model.ID.ValueString(), zoneName),
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sdnSubnetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state sdnSubnetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sdnSubnetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sdnSubnetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().Subnets().Update(ctx, plan.VNet.ValueString(), plan.ID.ValueString(), plan.exportToUpdateBody())
+	if err != nil {
+		sdn.AddAPIError(&resp.Diagnostics,
+			"Error Updating SDN Subnet",
+			fmt.Sprintf("Failed to update SDN subnet %s", plan.ID.ValueString()),
+			err, attributePath,
+		)
+		return
+	}
+
+	r.read(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sdnSubnetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sdnSubnetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().Subnets().Delete(ctx, state.VNet.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			if !r.quietNotFound {
+				sdn.AddWarning(&resp.Diagnostics, r.strict,
+					"SDN Subnet Not Found",
+					fmt.Sprintf("SDN subnet %s does not exist, skipping deletion", state.ID.ValueString()),
+				)
+			}
+		} else {
+			resp.Diagnostics.AddError(
+				"Error Deleting SDN Subnet",
+				fmt.Sprintf("Failed to delete SDN subnet %s: %s", state.ID.ValueString(), err),
+			)
+		}
+		return
+	}
+}
+
+// ImportState imports an existing SDN subnet by its `<vnet>/<subnet>` identifier, the subnet being identified
+// either by its `<zone>-<network>-<mask>` Proxmox identifier or by its CIDR.
+func (r *sdnSubnetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	vnet, subnet, ok := strings.Cut(req.ID, "/")
+	if !ok || vnet == "" || subnet == "" {
+		resp.Diagnostics.AddError(
+			"Invalid SDN Subnet Import ID",
+			fmt.Sprintf("Expected the import ID in the `<vnet>/<subnet>` format, got %s", req.ID),
+		)
+		return
+	}
+
+	// Both forms are compared as parsed CIDRs, so that differently written IPv6 networks still match.
+	cidr, err := netip.ParsePrefix(subnet)
+	if err != nil {
+		_, cidr, err = subnets.ParseID(subnet)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid SDN Subnet Import ID",
+			fmt.Sprintf("Expected the subnet of the import ID %s to be a CIDR or a Proxmox subnet identifier: %s", req.ID, err),
+		)
+		return
+	}
+
+	list, err := r.client.Cluster().SDN().Subnets().List(ctx, vnet)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing SDN Subnet",
+			fmt.Sprintf("Failed to list SDN subnets of VNet %s: %s", vnet, err),
+		)
+		return
+	}
+
+	model := sdnSubnetResourceModel{VNet: types.StringValue(vnet)}
+
+	for _, body := range list {
+		if prefix, err := body.Prefix(); err == nil && prefix == cidr {
+			model.importFromSdnSubnetBody(body)
+			model.VNet = types.StringValue(vnet)
+		}
+	}
+
+	if model.ID.IsNull() {
+		resp.Diagnostics.AddError(
+			"SDN Subnet Not Found",
+			fmt.Sprintf("SDN subnet %s does not exist in VNet %s", subnet, vnet),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
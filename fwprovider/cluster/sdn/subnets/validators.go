@@ -0,0 +1,25 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_subnets
+
+import (
+	"net/netip"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// cidrValidator validates that a subnet is given in the CIDR notation.
+func cidrValidator() validator.String {
+	return validators.NewParseValidator(netip.ParsePrefix, "must be a valid IPv4 or IPv6 CIDR")
+}
+
+// addressValidator validates that an address, e.g. a gateway, is a plain IPv4 or IPv6 address.
+func addressValidator() validator.String {
+	return validators.NewParseValidator(netip.ParseAddr, "must be a valid IPv4 or IPv6 address")
+}
@@ -0,0 +1,362 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/dns"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+	proxmoxnodes "github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// bridgeInterfaceTypes lists the network interface types that can be used as a bridge of a SDN zone.
+var bridgeInterfaceTypes = []string{"bridge", "OVSBridge"}
+
+// checkAdvertiseSubnets warns when an EVPN zone advertises its subnets, but none of its VNets has any subnet.
+// This usually means that the zone was applied before its subnets were created.
+func (r *sdnZoneResource) checkAdvertiseSubnets(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	if !r.apiValidation || model.EVPN == nil || !model.EVPN.AdvertiseSubnets.ValueBool() {
+		return
+	}
+
+	zoneName := model.Name.ValueString()
+
+	vnets, err := r.client.Cluster().SDN().VNets().ListByZone(ctx, zoneName)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Zone Subnets",
+			fmt.Sprintf("Failed to list SDN VNets of zone %s", zoneName),
+			err,
+		)
+		return
+	}
+
+	for _, vnet := range vnets {
+		subnets, err := r.client.Cluster().SDN().Subnets().List(ctx, vnet.Name)
+		if err != nil {
+			sdn.AddBestEffortWarning(diags,
+				"Unable to Validate SDN Zone Subnets",
+				fmt.Sprintf("Failed to list SDN subnets of VNet %s", vnet.Name),
+				err,
+			)
+			return
+		}
+
+		if len(subnets) > 0 {
+			return
+		}
+	}
+
+	sdn.AddWarning(diags, r.strict,
+		"SDN Zone Without Subnets",
+		fmt.Sprintf("SDN zone %s has `advertise_subnets` enabled, but none of its VNets has any subnet, "+
+			"so there is nothing to advertise. Create the subnets and re-apply the zone if this is unexpected.", zoneName),
+	)
+}
+
+// checkReferences reports the DNS servers and the IPAM referenced by the zone that don't exist,
+// as Proxmox rejects them with an error that doesn't name the missing object.
+func (r *sdnZoneResource) checkReferences(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	if isSet(model.DNS) || isSet(model.ReverseDNS) {
+		r.checkDNSReferences(ctx, model, diags)
+	}
+
+	if isSet(model.IPAM) && model.IPAM.ValueString() != noIPAM {
+		r.checkIPAMReference(ctx, model.IPAM.ValueString(), diags)
+	}
+}
+
+func (r *sdnZoneResource) checkDNSReferences(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	list, err := r.client.Cluster().SDN().DNS().List(ctx)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Zone DNS",
+			"Failed to list SDN DNS servers",
+			err,
+		)
+		return
+	}
+
+	refs := []struct {
+		attr  string
+		value types.String
+	}{
+		{"dns", model.DNS},
+		{"reversedns", model.ReverseDNS},
+	}
+
+	for _, ref := range refs {
+		if !isSet(ref.value) {
+			continue
+		}
+
+		name := ref.value.ValueString()
+		if !slices.ContainsFunc(list, func(d *dns.SdnDnsBody) bool { return d.Name == name }) {
+			sdn.AddWarning(diags, r.strict,
+				"SDN DNS Server Not Found",
+				fmt.Sprintf("SDN DNS server %s referenced by `%s` does not exist.", name, ref.attr),
+			)
+		}
+	}
+}
+
+func (r *sdnZoneResource) checkIPAMReference(ctx context.Context, name string, diags *diag.Diagnostics) {
+	list, err := r.client.Cluster().SDN().IPAMs().List(ctx)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Zone IPAM",
+			"Failed to list SDN IPAMs",
+			err,
+		)
+		return
+	}
+
+	if !slices.ContainsFunc(list, func(i *ipams.SdnIpamBody) bool { return i.Name == name }) {
+		sdn.AddWarning(diags, r.strict,
+			"SDN IPAM Not Found",
+			fmt.Sprintf("SDN IPAM %s referenced by `ipam` does not exist.", name),
+		)
+	}
+}
+
+// checkDHCPRanges warns when a simple zone enables DHCP, but none of the subnets of its VNets has a DHCP range.
+// The DHCP server only leases the addresses of the subnet ranges, so it has nothing to serve without them.
+func (r *sdnZoneResource) checkDHCPRanges(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	if !r.apiValidation || model.Simple == nil || !isSet(model.Simple.AutomaticDHCP) {
+		return
+	}
+
+	zoneName := model.Name.ValueString()
+
+	vnets, err := r.client.Cluster().SDN().VNets().ListByZone(ctx, zoneName)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Zone DHCP",
+			fmt.Sprintf("Failed to list SDN VNets of zone %s", zoneName),
+			err,
+		)
+		return
+	}
+
+	for _, vnet := range vnets {
+		subnets, err := r.client.Cluster().SDN().Subnets().List(ctx, vnet.Name)
+		if err != nil {
+			sdn.AddBestEffortWarning(diags,
+				"Unable to Validate SDN Zone DHCP",
+				fmt.Sprintf("Failed to list SDN subnets of VNet %s", vnet.Name),
+				err,
+			)
+			return
+		}
+
+		for _, subnet := range subnets {
+			if len(subnet.DHCPRange) > 0 {
+				return
+			}
+		}
+	}
+
+	sdn.AddWarning(diags, r.strict,
+		"SDN Zone DHCP Without Ranges",
+		fmt.Sprintf("SDN zone %s has DHCP enabled, but none of the subnets of its VNets has a DHCP range, "+
+			"so no address can be leased. DHCP also requires a VNet in the zone, a subnet in the VNet "+
+			"with a DHCP range, and the zone to be applied after them.", zoneName),
+	)
+}
+
+// checkEvpnCollisions reports the other EVPN zones using the same VRF VXLAN ID or the same anycast MAC
+// address as the planned zone, as their VRFs or gateways would collide. Collisions are errors when the zone
+// is created, so that they never reach the EVPN fabric, and warnings for the existing zones.
+func (r *sdnZoneResource) checkEvpnCollisions(
+	ctx context.Context,
+	zoneName string,
+	evpn *sdnZoneEvpnModel,
+	creating bool,
+	diags *diag.Diagnostics,
+) {
+	vrfVxlan := evpn.vrfVxlan()
+	checkVrfVxlan := !vrfVxlan.IsNull() && !vrfVxlan.IsUnknown()
+	checkMac := isSet(evpn.Mac)
+
+	if !checkVrfVxlan && !checkMac {
+		return
+	}
+
+	list, err := r.client.Cluster().SDN().Zones().List(ctx)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Zone VRF",
+			"Failed to list SDN zones",
+			err,
+		)
+		return
+	}
+
+	report := func(summary string, detail string) {
+		if creating {
+			diags.AddError(summary, detail)
+		} else {
+			sdn.AddWarning(diags, r.strict, summary, detail)
+		}
+	}
+
+	for _, zone := range list {
+		if zone.Name == zoneName {
+			continue
+		}
+
+		if checkVrfVxlan && zone.VrfVxlan != nil && *zone.VrfVxlan == vrfVxlan.ValueInt32() {
+			report(
+				"SDN Zone VRF Collision",
+				fmt.Sprintf("VRF VXLAN ID %d is already used by the VRF %s of SDN zone %s.",
+					vrfVxlan.ValueInt32(), evpnVrfName(zone.Name), zone.Name),
+			)
+		}
+
+		if checkMac && zone.Mac != nil && strings.EqualFold(*zone.Mac, evpn.Mac.ValueString()) {
+			report(
+				"SDN Zone MAC Address Collision",
+				fmt.Sprintf("Anycast MAC address %s is already used by SDN zone %s.", evpn.Mac.ValueString(), zone.Name),
+			)
+		}
+	}
+}
+
+// isSet reports whether a planned string value is known and not null.
+func isSet(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+// spansAllNodes reports whether a zone with the given nodes spans all nodes of the cluster, which is the case
+// when it has no nodes, or an empty list of them, e.g. from a filtered list of online nodes.
+func spansAllNodes(nodes types.List) bool {
+	return nodes.IsNull() || !nodes.IsUnknown() && len(nodes.Elements()) == 0
+}
+
+// onlineNodes returns the names of the online nodes of the cluster, the nodes spanned by a zone without nodes.
+func (r *sdnZoneResource) onlineNodes(ctx context.Context) ([]string, error) {
+	list, err := r.client.Node("").ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeNames []string
+
+	for _, node := range list {
+		if node.Status == nil || *node.Status == "online" {
+			nodeNames = append(nodeNames, node.Name)
+		}
+	}
+
+	return nodeNames, nil
+}
+
+// zoneNodeNames returns the names of the nodes spanned by a zone with the given nodes, i.e. all online nodes
+// of the cluster when the zone has no nodes. Only a failure to list the cluster nodes is returned as an error,
+// so that the callers can report it their own way.
+func (r *sdnZoneResource) zoneNodeNames(ctx context.Context, nodes types.List, diags *diag.Diagnostics) ([]string, error) {
+	if spansAllNodes(nodes) {
+		return r.onlineNodes(ctx)
+	}
+
+	var nodeNames []string
+
+	diags.Append(nodes.ElementsAs(ctx, &nodeNames, false)...)
+
+	return nodeNames, nil
+}
+
+// findBridge returns the bridge with the given name among the network interfaces of a node, or nil if there is none.
+func findBridge(ifaces []*proxmoxnodes.NetworkInterfaceListResponseData, bridge string) *proxmoxnodes.NetworkInterfaceListResponseData {
+	i := slices.IndexFunc(ifaces, func(iface *proxmoxnodes.NetworkInterfaceListResponseData) bool {
+		return iface.Iface == bridge && slices.Contains(bridgeInterfaceTypes, iface.Type)
+	})
+	if i < 0 {
+		return nil
+	}
+
+	return ifaces[i]
+}
+
+// checkBridge reports the nodes of the zone on which the bridge doesn't exist. A bridge that isn't VLAN-aware
+// is fine, Proxmox then creates a VLAN sub-interface per tag. When the zone has no nodes configured, it spans
+// the whole cluster and the bridge is checked on all online nodes.
+func (r *sdnZoneResource) checkBridge(ctx context.Context, bridge string, zoneNodes types.List, diags *diag.Diagnostics) {
+	nodeNames, err := r.zoneNodeNames(ctx, zoneNodes, diags)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Validate SDN Zone Bridge",
+			"Failed to list cluster nodes",
+			err,
+		)
+		return
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	var missing []string
+
+	for _, nodeName := range nodeNames {
+		ifaces, err := r.client.Node(nodeName).ListNetworkInterfaces(ctx)
+		if err != nil {
+			sdn.AddBestEffortWarning(diags,
+				"Unable to Validate SDN Zone Bridge",
+				fmt.Sprintf("Failed to list network interfaces of node %s", nodeName),
+				err,
+			)
+			return
+		}
+
+		if findBridge(ifaces, bridge) == nil {
+			missing = append(missing, nodeName)
+		}
+	}
+
+	if len(missing) > 0 {
+		sdn.AddWarning(diags, r.strict,
+			"SDN Zone Bridge Not Found",
+			fmt.Sprintf("Bridge %s does not exist on nodes: %s", bridge, strings.Join(missing, ", ")),
+		)
+	}
+}
+
+// addUnsupportedTypeError adds an error telling that the cluster doesn't support the type of the zone, which
+// Proxmox only reports as an invalid parameter. It returns false, without adding anything, if the type is
+// supported or the supported types can't be determined.
+func (r *sdnZoneResource) addUnsupportedTypeError(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) bool {
+	if r.capabilities == nil {
+		return false
+	}
+
+	capabilities, err := r.capabilities.Get(ctx)
+	if err != nil || !capabilities.Available {
+		return false
+	}
+
+	zoneType := model.zoneType()
+	if capabilities.SupportsZoneType(zoneType) {
+		return false
+	}
+
+	diags.AddError(
+		"Unsupported SDN Zone Type",
+		fmt.Sprintf("The Proxmox version of the cluster doesn't support %s SDN zones, SDN zone %s can't be created. "+
+			"Supported zone types: %s.", zoneType, model.Name.ValueString(), strings.Join(capabilities.ZoneTypes, ", ")),
+	)
+
+	return true
+}
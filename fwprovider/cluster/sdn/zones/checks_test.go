@@ -0,0 +1,302 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	proxmoxsdn "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+// newTestClient creates a client connected to a test server using the given handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) proxmox.Client {
+	t.Helper()
+
+	return proxmox.NewClient(sdntest.NewAPIClient(t, handler), nil, "")
+}
+
+func TestCheckReferences(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/dns/":
+			_, _ = w.Write([]byte(`{"data":[{"dns":"powerdns","type":"powerdns"}]}`))
+		case "/api2/json/cluster/sdn/ipams/":
+			_, _ = w.Write([]byte(`{"data":[{"ipam":"pve","type":"pve"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		model    sdnZoneResourceModel
+		strict   bool
+		warnings int
+		errors   int
+	}{
+		{"no references", sdnZoneResourceModel{}, false, 0, 0},
+		{"existing references", sdnZoneResourceModel{
+			DNS:  types.StringValue("powerdns"),
+			IPAM: types.StringValue("pve"),
+		}, false, 0, 0},
+		{"unknown references", sdnZoneResourceModel{
+			DNS:  types.StringUnknown(),
+			IPAM: types.StringUnknown(),
+		}, false, 0, 0},
+		{"missing references", sdnZoneResourceModel{
+			DNS:        types.StringValue("pdns"),
+			ReverseDNS: types.StringValue("powerdns"),
+			IPAM:       types.StringValue("netbox"),
+		}, false, 2, 0},
+		{"missing references in strict mode", sdnZoneResourceModel{
+			ReverseDNS: types.StringValue("pdns"),
+		}, true, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client, strict: tt.strict}
+			diags := diag.Diagnostics{}
+
+			r.checkReferences(t.Context(), &tt.model, &diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+			require.Equal(t, tt.errors, diags.ErrorsCount(), "%v", diags)
+		})
+	}
+}
+
+func TestBestEffortLookups(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Permission check failed", http.StatusForbidden)
+	})
+
+	// Failed lookups are only warnings, even in strict mode.
+	r := &sdnZoneResource{client: client, strict: true}
+	diags := diag.Diagnostics{}
+
+	model := sdnZoneResourceModel{
+		Name: types.StringValue("zone1"),
+		DNS:  types.StringValue("powerdns"),
+		IPAM: types.StringValue("pve"),
+	}
+	evpn := sdnZoneEvpnModel{ControllerASN: types.Int64Value(65000)}
+
+	r.checkReferences(t.Context(), &model, &diags)
+	r.resolveControllerASN(t.Context(), "evpn1", &evpn, &diags)
+	r.listVNets(t.Context(), &model, &diags)
+
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Equal(t, 4, diags.WarningsCount(), "%v", diags)
+	require.True(t, evpn.ControllerASN.IsNull())
+	require.True(t, model.VNets.IsNull())
+}
+
+func TestCheckBridge(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/nodes":
+			_, _ = w.Write([]byte(`{"data":[{"node":"pve1","status":"online"},{"node":"pve2","status":"online"}]}`))
+		case "/api2/json/nodes/pve1/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","bridge_vlan_aware":1},` +
+				`{"iface":"vmbr1","type":"bridge"},{"iface":"ovs0","type":"OVSBridge"}]}`))
+		case "/api2/json/nodes/pve2/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","bridge_vlan_aware":1},` +
+				`{"iface":"vmbr1","type":"bridge","bridge_vlan_aware":0}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		bridge   string
+		nodes    types.List
+		summary  string
+		detail   string
+		warnings int
+	}{
+		{"VLAN-aware bridge", "vmbr0", types.ListNull(types.StringType), "", "", 0},
+		// Proxmox creates VLAN sub-interfaces on a bridge that isn't VLAN-aware.
+		{"not VLAN-aware bridge", "vmbr1", types.ListNull(types.StringType), "", "", 0},
+		{"OVS bridge", "ovs0", types.ListValueMust(types.StringType, []attr.Value{types.StringValue("pve1")}), "", "", 0},
+		{"missing bridge", "ovs0", types.ListNull(types.StringType), "SDN Zone Bridge Not Found", "pve2", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client}
+			diags := diag.Diagnostics{}
+
+			r.checkBridge(t.Context(), tt.bridge, tt.nodes, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+
+			if tt.warnings > 0 {
+				require.Equal(t, tt.summary, diags.Warnings()[0].Summary())
+				require.Contains(t, diags.Warnings()[0].Detail(), tt.detail)
+			}
+
+			// With `sdn_strict`, the same findings are errors.
+			r.strict = true
+			diags = diag.Diagnostics{}
+
+			r.checkBridge(t.Context(), tt.bridge, tt.nodes, &diags)
+			require.Zero(t, diags.WarningsCount(), "%v", diags)
+			require.Equal(t, tt.warnings, diags.ErrorsCount(), "%v", diags)
+		})
+	}
+}
+
+func TestCheckDHCPRanges(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/cluster/sdn/vnets/":
+			_, _ = w.Write([]byte(`{"data":[` +
+				`{"vnet":"vnet1","zone":"ranges"},{"vnet":"vnet2","zone":"ranges"},` +
+				`{"vnet":"vnet3","zone":"noranges"}]}`))
+		case "/api2/json/cluster/sdn/vnets/vnet1/subnets":
+			_, _ = w.Write([]byte(`{"data":[{"subnet":"ranges-10.0.1.0-24","cidr":"10.0.1.0/24"}]}`))
+		case "/api2/json/cluster/sdn/vnets/vnet2/subnets":
+			_, _ = w.Write([]byte(`{"data":[{"subnet":"ranges-10.0.2.0-24","cidr":"10.0.2.0/24",` +
+				`"dhcp-range":[{"start-address":"10.0.2.100","end-address":"10.0.2.200"}]}]}`))
+		case "/api2/json/cluster/sdn/vnets/vnet3/subnets":
+			_, _ = w.Write([]byte(`{"data":[{"subnet":"noranges-10.0.3.0-24","cidr":"10.0.3.0/24"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		zone     string
+		dhcp     types.String
+		warnings int
+	}{
+		{"DHCP disabled", "noranges", types.StringNull(), 0},
+		{"subnet with range", "ranges", types.StringValue("dnsmasq"), 0},
+		{"no subnet with range", "noranges", types.StringValue("dnsmasq"), 1},
+		{"no VNets", "empty", types.StringValue("dnsmasq"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client, apiValidation: true}
+			diags := diag.Diagnostics{}
+
+			r.checkDHCPRanges(t.Context(), &sdnZoneResourceModel{
+				Name:   types.StringValue(tt.zone),
+				Simple: &sdnZoneSimpleModel{AutomaticDHCP: tt.dhcp},
+			}, &diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+			require.False(t, diags.HasError())
+		})
+	}
+}
+
+func TestCheckEvpnCollisions(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[` +
+			`{"zone":"evpn1","type":"evpn","vrf-vxlan":100,"mac":"BC:24:11:00:00:01"},` +
+			`{"zone":"evpn2","type":"evpn","vrf-vxlan":200},` +
+			`{"zone":"simple1","type":"simple"}]}`))
+	})
+
+	tests := []struct {
+		name     string
+		zone     string
+		vrfVxlan types.Int32
+		mac      types.String
+		creating bool
+		warnings int
+		errors   int
+	}{
+		{"unique", "evpn3", types.Int32Value(300), types.StringValue("BC:24:11:00:00:03"), true, 0, 0},
+		{"own zone", "evpn1", types.Int32Value(100), types.StringValue("BC:24:11:00:00:01"), false, 0, 0},
+		{"VRF collision on create", "evpn3", types.Int32Value(200), types.StringNull(), true, 0, 1},
+		{"VRF collision on update", "evpn3", types.Int32Value(200), types.StringNull(), false, 1, 0},
+		{"MAC collision on create", "evpn3", types.Int32Null(), types.StringValue("bc:24:11:00:00:01"), true, 0, 1},
+		{"MAC collision on update", "evpn3", types.Int32Null(), types.StringValue("BC:24:11:00:00:01"), false, 1, 0},
+		{"both collisions", "evpn3", types.Int32Value(100), types.StringValue("BC:24:11:00:00:01"), true, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client}
+			diags := diag.Diagnostics{}
+
+			r.checkEvpnCollisions(t.Context(), tt.zone, &sdnZoneEvpnModel{VrfVxlan: tt.vrfVxlan, Mac: tt.mac}, tt.creating, &diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount(), "%v", diags)
+			require.Equal(t, tt.errors, diags.ErrorsCount(), "%v", diags)
+		})
+	}
+}
+
+func TestAddUnsupportedTypeError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		version string
+	}{
+		{"known version", "8.2.4"},
+		// The zone types of an older version are unknown, the zone is left to Proxmox to validate.
+		{"older version", "6.4-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api2/json/version" {
+					_, _ = w.Write([]byte(`{"data":{"version":"` + tt.version + `"}}`))
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"data":[]}`))
+			})
+
+			r := &sdnZoneResource{client: client, capabilities: proxmoxsdn.NewCapabilitiesCache(client.Cluster().SDN())}
+			diags := diag.Diagnostics{}
+
+			for _, model := range []sdnZoneResourceModel{
+				{Name: types.StringValue("zone1"), Simple: &sdnZoneSimpleModel{}},
+				{Name: types.StringValue("zone2"), EVPN: &sdnZoneEvpnModel{}},
+			} {
+				require.False(t, r.addUnsupportedTypeError(t.Context(), &model, &diags))
+			}
+
+			require.Empty(t, diags)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// writeStatus writes a raw response with a custom reason phrase, the way Proxmox reports errors.
+func writeStatus(t *testing.T, w http.ResponseWriter, code int, reason string) {
+	t.Helper()
+
+	conn, buf, err := w.(http.Hijacker).Hijack()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(buf, "HTTP/1.1 %d %s\r\nContent-Length: 0\r\nConnection: close\r\n\r\n", code, reason)
+	require.NoError(t, err)
+	require.NoError(t, buf.Flush())
+}
+
+// TestCreateWaitsForController is not parallel, as it shortens the delay shared by all zone resources.
+func TestCreateWaitsForController(t *testing.T) {
+	delay := controllerWaitDelay
+	controllerWaitDelay = 10 * time.Millisecond
+
+	t.Cleanup(func() { controllerWaitDelay = delay })
+
+	tests := []struct {
+		name     string
+		failures int32
+		reason   string
+		attempts int32
+		err      bool
+	}{
+		{"controller created meanwhile", 2, "controller evpn1 does not exist", 3, false},
+		{"controller never created", controllerWaitAttempts, "controller evpn1 does not exist", controllerWaitAttempts, true},
+		{"other error", 1, "zone ID 'zone1' already defined", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) <= tt.failures {
+					writeStatus(t, w, http.StatusInternalServerError, tt.reason)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"data":null}`))
+			})
+
+			r := &sdnZoneResource{client: client}
+			err := r.create(t.Context(), &sdnZoneResourceModel{
+				Name:  types.StringValue("zone1"),
+				Nodes: types.ListNull(types.StringType),
+				EVPN: &sdnZoneEvpnModel{
+					Controller: types.StringValue("evpn1"),
+					VrfVxlan:   types.Int32Value(100),
+					Exitnodes:  types.ListNull(types.StringType),
+				},
+			}, &diag.Diagnostics{})
+
+			require.Equal(t, tt.err, err != nil, "%v", err)
+			require.Equal(t, tt.attempts, attempts.Load())
+		})
+	}
+}
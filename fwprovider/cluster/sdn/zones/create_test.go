@@ -0,0 +1,190 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateKeepsStateWhenReadFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"data":null}`))
+			return
+		}
+
+		http.Error(w, "", http.StatusBadGateway)
+	})
+
+	r := &sdnZoneResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &sdnZoneResourceModel{
+		Name:      types.StringValue("zone1"),
+		Type:      types.StringUnknown(),
+		Nodes:     types.ListNull(types.StringType),
+		ListVNets: types.BoolValue(false),
+		VNets:     types.ListUnknown(types.StringType),
+		Simple:    &sdnZoneSimpleModel{AutomaticDHCP: types.StringNull()},
+	}).HasError())
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.Equal(t, 2, resp.Diagnostics.WarningsCount())
+	require.Equal(t, "Error Reading SDN Zone", resp.Diagnostics.Warnings()[0].Summary())
+	require.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "Failed to read SDN zone zone1")
+
+	var state sdnZoneResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Equal(t, "zone1", state.Name.ValueString())
+	require.True(t, state.Type.IsNull())
+	require.True(t, state.VNets.IsNull())
+	require.NotNil(t, state.Simple)
+}
+
+func TestCreateVerify(t *testing.T) {
+	t.Parallel()
+
+	const upid = "UPID:pve1:0000C4F2:0001A2B3:66000000:reloadnetworkall::root@pam:"
+
+	tests := []struct {
+		name     string
+		exitCode string
+		summary  string
+	}{
+		{"verified", "OK", ""},
+		{"apply failed", "some nodes failed to reload", "Error Applying SDN Configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/api2/json/cluster/sdn":
+					_, _ = fmt.Fprintf(w, `{"data":%q}`, upid)
+				case r.URL.Path == "/api2/json/nodes/pve1/tasks/"+upid+"/status":
+					_, _ = fmt.Fprintf(w, `{"data":{"status":"stopped","exitstatus":%q}}`, tt.exitCode)
+				case r.URL.Path == "/api2/json/nodes/pve1/sdn/zones":
+					_, _ = w.Write([]byte(`{"data":[{"zone":"zone1","status":"available"}]}`))
+				case r.Method == http.MethodPost:
+					_, _ = w.Write([]byte(`{"data":null}`))
+				default:
+					_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple","nodes":"pve1"}}`))
+				}
+			})
+
+			r := &sdnZoneResource{client: client}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(ctx, &sdnZoneResourceModel{
+				Name:           types.StringValue("zone1"),
+				Type:           types.StringUnknown(),
+				Nodes:          types.ListValueMust(types.StringType, []attr.Value{types.StringValue("pve1")}),
+				ListVNets:      types.BoolValue(false),
+				VNets:          types.ListUnknown(types.StringType),
+				VerifyOnCreate: types.BoolValue(true),
+				Simple:         &sdnZoneSimpleModel{AutomaticDHCP: types.StringNull()},
+			}).HasError())
+
+			resp := &resource.CreateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+			if tt.summary == "" {
+				require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			} else {
+				require.Equal(t, 1, resp.Diagnostics.ErrorsCount(), "%v", resp.Diagnostics)
+				require.Equal(t, tt.summary, resp.Diagnostics.Errors()[0].Summary())
+			}
+
+			// The created zone is stored in both cases, a failed verification taints it.
+			var state sdnZoneResourceModel
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			require.Equal(t, "simple", state.Type.ValueString())
+			require.True(t, state.VerifyOnCreate.ValueBool())
+		})
+	}
+}
+
+func TestCreateError(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		writeStatus(t, w, http.StatusInternalServerError, "create sdn zone object failed: bridge 'vmbr9' does not exist")
+	})
+
+	r := &sdnZoneResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &sdnZoneResourceModel{
+		Name:      types.StringValue("zone1"),
+		Type:      types.StringUnknown(),
+		Nodes:     types.ListNull(types.StringType),
+		ListVNets: types.BoolValue(false),
+		VNets:     types.ListUnknown(types.StringType),
+		VLAN:      &sdnZoneVlanModel{Bridge: types.StringValue("vmbr9")},
+	}).HasError())
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	require.Equal(t, 1, resp.Diagnostics.ErrorsCount(), "%v", resp.Diagnostics)
+	require.Equal(t, "Error Creating SDN Zone", resp.Diagnostics.Errors()[0].Summary())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Failed to create vlan SDN zone zone1: ")
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "bridge 'vmbr9' does not exist")
+	require.True(t, resp.State.Raw.IsNull(), "a zone that failed to be created must not be stored")
+}
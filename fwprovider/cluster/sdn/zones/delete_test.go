@@ -0,0 +1,210 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteWithoutApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// get handles the reads of the zone after its deletion was staged, simulating how different
+		// Proxmox versions report it.
+		get func(t *testing.T, w http.ResponseWriter)
+	}{
+		{"returned from the running config", func(_ *testing.T, w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple"}}`))
+		}},
+		{"inconsistent error", func(t *testing.T, w http.ResponseWriter) {
+			writeStatus(t, w, http.StatusInternalServerError, "unable to read SDN zone configuration")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			var (
+				deleted atomic.Bool
+				reads   atomic.Int32
+			)
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/api2/json/cluster/sdn/zones/zone1", r.URL.Path)
+
+				switch {
+				case r.Method == http.MethodDelete && deleted.Load():
+					// a repeated deletion fails, as the zone is already gone from the staged configuration
+					writeStatus(t, w, http.StatusInternalServerError, "unable to delete SDN zone")
+				case r.Method == http.MethodDelete:
+					deleted.Store(true)
+					_, _ = w.Write([]byte(`{"data":null}`))
+				case !deleted.Load():
+					_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple"}}`))
+				case r.URL.Query().Get("pending") == "1":
+					reads.Add(1)
+					_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple","state":"deleted"}}`))
+				default:
+					reads.Add(1)
+					tt.get(t, w)
+				}
+			})
+
+			r := &sdnZoneResource{client: client}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			model := sdnZoneResourceModel{
+				Name:      types.StringValue("zone1"),
+				Type:      types.StringValue("simple"),
+				Nodes:     types.ListNull(types.StringType),
+				ListVNets: types.BoolValue(false),
+				VNets:     types.ListNull(types.StringType),
+				ReadOnly:  types.BoolValue(false),
+				Simple:    &sdnZoneSimpleModel{},
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, &model).HasError())
+
+			for range 2 {
+				resp := &resource.DeleteResponse{State: state}
+				r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+				require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				require.Zero(t, resp.Diagnostics.WarningsCount())
+			}
+
+			diags := diag.Diagnostics{}
+			reads.Store(0)
+
+			r.read(ctx, &model, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, 1, diags.WarningsCount())
+			require.Nil(t, model.Simple, "the zone must be removed from the state")
+			require.Equal(t, int32(1), reads.Load(), "the zone must be read with a single request")
+		})
+	}
+}
+
+func TestDeleteMissingZone(t *testing.T) {
+	t.Parallel()
+
+	notFound := func(_ *testing.T, w http.ResponseWriter) {
+		http.Error(w, "", http.StatusNotFound)
+	}
+
+	tests := []struct {
+		name          string
+		delete        func(t *testing.T, w http.ResponseWriter)
+		quietNotFound bool
+		warnings      int
+	}{
+		{"not found status", notFound, false, 1},
+		{"does not exist error", func(t *testing.T, w http.ResponseWriter) {
+			writeStatus(t, w, http.StatusInternalServerError, "sdn zone object ID 'zone1' does not exist")
+		}, false, 1},
+		{"quiet", notFound, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(t, "/api2/json/cluster/sdn/zones/zone1", r.URL.Path)
+
+				tt.delete(t, w)
+			})
+
+			r := &sdnZoneResource{client: client, quietNotFound: tt.quietNotFound}
+
+			schemaResp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError())
+
+			// A zone of a type unknown to the provider has none of the zone blocks.
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, &sdnZoneResourceModel{
+				Name:      types.StringValue("zone1"),
+				Type:      types.StringValue("faucet"),
+				Nodes:     types.ListNull(types.StringType),
+				ListVNets: types.BoolValue(false),
+				VNets:     types.ListNull(types.StringType),
+				ReadOnly:  types.BoolValue(false),
+			}).HasError())
+
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.Equal(t, tt.warnings, resp.Diagnostics.WarningsCount())
+
+			if tt.warnings > 0 {
+				require.Equal(t, "SDN Zone Not Found", resp.Diagnostics.Warnings()[0].Summary())
+			}
+		})
+	}
+}
+
+// TestDeleteZoneUsedByVNet checks that the replacement of a zone used by a VNet, e.g. on an IPAM change with
+// `ipam_change_requires_replace`, fails on the deletion rather than recreating the VNet.
+func TestDeleteZoneUsedByVNet(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			writeStatus(t, w, http.StatusInternalServerError, "zone zone1 is used by vnet vnet1")
+		default:
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple","ipam":"pve"}}`))
+		}
+	})
+
+	r := &sdnZoneResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &sdnZoneResourceModel{
+		Name:        types.StringValue("zone1"),
+		Type:        types.StringValue("simple"),
+		IPAM:        types.StringValue("pve"),
+		IPAMReplace: types.BoolValue(true),
+		Nodes:       types.ListNull(types.StringType),
+		ListVNets:   types.BoolValue(false),
+		VNets:       types.ListNull(types.StringType),
+		ReadOnly:    types.BoolValue(false),
+		Simple:      &sdnZoneSimpleModel{},
+	}).HasError())
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Error Deleting SDN Zone", resp.Diagnostics.Errors()[0].Summary())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "is used by vnet vnet1")
+}
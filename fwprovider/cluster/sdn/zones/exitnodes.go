@@ -0,0 +1,51 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resolveAutoExitnodes selects the exit nodes of an EVPN zone with `exitnodes_auto`: the nodes of the zone,
+// or all online nodes of the cluster for a zone without nodes. The selected nodes are set as the exit nodes
+// of the model, sent to Proxmox as if they were configured explicitly.
+func (r *sdnZoneResource) resolveAutoExitnodes(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	if model.EVPN == nil || !model.EVPN.ExitnodesAuto.ValueBool() {
+		return
+	}
+
+	if !spansAllNodes(model.Nodes) {
+		model.EVPN.Exitnodes = model.Nodes
+		return
+	}
+
+	nodeNames, err := r.onlineNodes(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to Select SDN Zone Exit Nodes",
+			fmt.Sprintf("Failed to list cluster nodes: %s", err),
+		)
+		return
+	}
+
+	if len(nodeNames) == 0 {
+		diags.AddError(
+			"Unable to Select SDN Zone Exit Nodes",
+			fmt.Sprintf("SDN zone %s has no nodes to select the exit nodes from", model.Name.ValueString()),
+		)
+		return
+	}
+
+	exitnodes, d := types.ListValueFrom(ctx, types.StringType, nodeNames)
+	diags.Append(d...)
+
+	model.EVPN.Exitnodes = exitnodes
+}
@@ -0,0 +1,79 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestResolveAutoExitnodes(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api2/json/nodes", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"data":[{"node":"pve1","status":"online"},{"node":"pve2","status":"online"},` +
+			`{"node":"pve3","status":"offline"}]}`))
+	})
+
+	stringList := func(values ...string) types.List {
+		list, d := types.ListValueFrom(t.Context(), types.StringType, values)
+		require.False(t, d.HasError())
+
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		auto      bool
+		nodes     types.List
+		exitnodes types.List
+	}{
+		{"disabled", false, stringList("pve1"), stringList("pve2")},
+		{"nodes of the zone", true, stringList("pve1", "pve3"), stringList("pve1", "pve3")},
+		{"online nodes of the cluster", true, types.ListNull(types.StringType), stringList("pve1", "pve2")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client}
+			model := sdnZoneResourceModel{
+				Name:  types.StringValue("zone1"),
+				Nodes: tt.nodes,
+				EVPN: &sdnZoneEvpnModel{
+					Exitnodes:     stringList("pve2"),
+					ExitnodesAuto: types.BoolValue(tt.auto),
+				},
+			}
+			diags := diag.Diagnostics{}
+
+			r.resolveAutoExitnodes(t.Context(), &model, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.exitnodes, model.EVPN.Exitnodes)
+
+			// the selection is kept when the zone is read back, it isn't stored by Proxmox
+			model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{
+				Name:      "zone1",
+				Type:      ptr.Ptr("evpn"),
+				Exitnodes: model.exportToSdnZoneBody(t.Context(), &diags).Exitnodes,
+			}, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.exitnodes, model.EVPN.Exitnodes)
+			require.Equal(t, types.BoolValue(tt.auto), model.EVPN.ExitnodesAuto)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportStateRecursive(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	sections := map[string]any{
+		"zones/zone1":         map[string]any{"zone": "zone1", "type": "simple"},
+		"vnets":               []map[string]any{{"vnet": "vnet1", "zone": "zone1"}, {"vnet": "vnet2", "zone": "zone2"}},
+		"vnets/vnet1/subnets": []map[string]any{{"subnet": "zone1-10.0.0.0-24", "cidr": "10.0.0.0/24"}},
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		data, ok := sections[strings.Trim(strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/"), "/")]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	})
+
+	r := &sdnZoneResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tests := []struct {
+		name     string
+		id       string
+		warnings int
+	}{
+		{"zone only", "zone1", 0},
+		{"recursive", "zone1?recursive=true", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.Equal(t, tt.warnings, resp.Diagnostics.WarningsCount())
+
+			var model sdnZoneResourceModel
+			require.False(t, resp.State.Get(ctx, &model).HasError())
+			require.Equal(t, types.StringValue("zone1"), model.Name)
+
+			if tt.warnings > 0 {
+				detail := resp.Diagnostics.Warnings()[0].Detail()
+				require.Contains(t, detail, "vnet1")
+				require.NotContains(t, detail, "vnet2")
+				require.Contains(t, detail, `id = "vnet1/zone1-10.0.0.0-24"`)
+			}
+		})
+	}
+}
@@ -10,32 +10,54 @@ import (
 	"context"
 	"strings"
 
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// INFO: Proxmox API provides additional type of SDN zone: "faucet"
-// But I don't see any documentation about it.
+// INFO: Proxmox API provides an additional, experimental type of SDN zone: "faucet", configured with
+// the "dp-id" (OpenFlow datapath ID) attribute. Faucet is an OpenFlow controller, it's supported by the
+// SDN controller resource, but the faucet zones aren't, as Proxmox doesn't generate any configuration for them.
 //
-// There are also "bridge-disable-mac-learning" and "dp-id" attributes
-// But I also don't know how to use them.
+// There is also the "bridge-disable-mac-learning" attribute, which isn't supported yet.
+//
+// EVPN zones have no gateway nodes distinct from the exit nodes: neither the zones nor the controllers
+// of the Proxmox API have such an attribute, the exit nodes are the gateways of the zone to the outside.
+//
+// Zones have no comment or description attribute either, unlike the VNets with their alias, so they can only
+// be annotated on the Terraform side.
+//
+// VXLAN zones only support unicast peers: the Proxmox API has no multicast group attribute, the generated
+// VXLAN interfaces always use the head-end replication to the `peers` of the zone.
 
 type sdnZoneResourceModel struct {
 	// Base attributes
-	Name       types.String        `tfsdk:"name"`
-	MTU        types.Int32         `tfsdk:"mtu"`
-	Nodes      types.List          `tfsdk:"nodes"`
-	IPAM       types.String        `tfsdk:"ipam"`
-	DNS        types.String        `tfsdk:"dns"`
-	ReverseDNS types.String        `tfsdk:"reversedns"`
-	DNSZone    types.String        `tfsdk:"dnszone"`
-	Simple     *sdnZoneSimpleModel `tfsdk:"simple"`
-	VLAN       *sdnZoneVlanModel   `tfsdk:"vlan"`
-	VXLAN      *sdnZoneVxlanModel  `tfsdk:"vxlan"`
-	QinQ       *sdnZoneQinQModel   `tfsdk:"qinq"`
-	EVPN       *sdnZoneEvpnModel   `tfsdk:"evpn"`
+	Name           types.String        `tfsdk:"name"`
+	Type           types.String        `tfsdk:"type"`
+	MTU            types.Int32         `tfsdk:"mtu"`
+	AutoMTU        types.Bool          `tfsdk:"auto_mtu"`
+	BridgeMTU      types.Bool          `tfsdk:"inherit_bridge_mtu"`
+	Jumbo          types.Bool          `tfsdk:"jumbo"`
+	ComputedMTU    types.Int32         `tfsdk:"computed_mtu"`
+	Nodes          types.List          `tfsdk:"nodes"`
+	IPAM           types.String        `tfsdk:"ipam"`
+	IPAMReplace    types.Bool          `tfsdk:"ipam_change_requires_replace"`
+	DNS            types.String        `tfsdk:"dns"`
+	ReverseDNS     types.String        `tfsdk:"reversedns"`
+	DNSZone        types.String        `tfsdk:"dnszone"`
+	ListVNets      types.Bool          `tfsdk:"list_vnets"`
+	VNets          types.List          `tfsdk:"vnets"`
+	ReadOnly       types.Bool          `tfsdk:"read_only"`
+	VerifyOnCreate types.Bool          `tfsdk:"verify_on_create"`
+	Simple         *sdnZoneSimpleModel `tfsdk:"simple"`
+	VLAN           *sdnZoneVlanModel   `tfsdk:"vlan"`
+	VXLAN          *sdnZoneVxlanModel  `tfsdk:"vxlan"`
+	QinQ           *sdnZoneQinQModel   `tfsdk:"qinq"`
+	EVPN           *sdnZoneEvpnModel   `tfsdk:"evpn"`
 }
 
 type sdnZoneSimpleModel struct {
@@ -46,27 +68,127 @@ type sdnZoneVlanModel struct {
 	Bridge types.String `tfsdk:"bridge"`
 }
 
+// defaultVxlanPort is the VXLAN tunnel UDP port used by Proxmox when none is configured.
+const defaultVxlanPort int32 = 4789
+
 type sdnZoneVxlanModel struct {
 	Peers types.List  `tfsdk:"peers"`
 	Port  types.Int32 `tfsdk:"port"`
 }
 
+// VLAN protocols of the service tag of QinQ zones, sent to Proxmox as is.
+// Proxmox uses 802.1q when none is configured.
+const (
+	vlanProtocol8021Q  = "802.1q"
+	vlanProtocol8021AD = "802.1ad"
+)
+
 type sdnZoneQinQModel struct {
 	Bridge       types.String `tfsdk:"bridge"`
 	Tag          types.Int32  `tfsdk:"tag"`
 	VlanProtocol types.String `tfsdk:"vlan_protocol"`
 }
 
+// evpnVrfName returns the name of the VRF Proxmox creates for an EVPN zone.
+func evpnVrfName(zone string) string {
+	return "vrf_" + zone
+}
+
 type sdnZoneEvpnModel struct {
 	Controller              types.String `tfsdk:"controller"`
 	VrfVxlan                types.Int32  `tfsdk:"vrf_vxlan"`
+	VfrVxlan                types.Int32  `tfsdk:"vfr_vxlan"`
+	Vrf                     types.String `tfsdk:"vrf"`
 	Mac                     types.String `tfsdk:"mac"`
 	Exitnodes               types.List   `tfsdk:"exitnodes"`
+	ExitnodesAuto           types.Bool   `tfsdk:"exitnodes_auto"`
 	ExitnodesPrimary        types.String `tfsdk:"exitnodes_primary"`
 	ExitnodesLocalRouting   types.Bool   `tfsdk:"exitnodes_local_routing"`
 	AdvertiseSubnets        types.Bool   `tfsdk:"advertise_subnets"`
 	DisableArpNdSuppression types.Bool   `tfsdk:"disable_arp_nd_suppression"`
 	RtImport                types.String `tfsdk:"rt_import"`
+	ResolveControllerASN    types.Bool   `tfsdk:"resolve_controller_asn"`
+	ControllerASN           types.Int64  `tfsdk:"controller_asn"`
+}
+
+// vrfVxlan returns the VRF VXLAN ID of the zone, set either by `vrf_vxlan` or by the deprecated `vfr_vxlan`.
+func (m *sdnZoneEvpnModel) vrfVxlan() types.Int32 {
+	if m.VrfVxlan.IsNull() {
+		return m.VfrVxlan
+	}
+
+	return m.VrfVxlan
+}
+
+// zoneBlockParams maps the parameters of the Proxmox API specific to a zone type to the attributes of its block.
+var zoneBlockParams = map[string]map[string]string{
+	"simple": {"dhcp": "dhcp"},
+	"vlan":   {"bridge": "bridge"},
+	"vxlan":  {"peers": "peers", "vxlan-port": "port"},
+	"qinq":   {"bridge": "bridge", "tag": "tag", "vlan-protocol": "vlan_protocol"},
+	"evpn": {
+		"controller":                 "controller",
+		"vrf-vxlan":                  "vrf_vxlan",
+		"mac":                        "mac",
+		"exitnodes":                  "exitnodes",
+		"exitnodes-primary":          "exitnodes_primary",
+		"exitnodes-local-routing":    "exitnodes_local_routing",
+		"advertise-subnets":          "advertise_subnets",
+		"disable-arp-nd-suppression": "disable_arp_nd_suppression",
+		"rt-import":                  "rt_import",
+	},
+}
+
+// attributePath returns the path of the attribute set from a parameter of the Proxmox API.
+func (m *sdnZoneResourceModel) attributePath(param string) (path.Path, bool) {
+	switch param {
+	case "zone":
+		return path.Root("name"), true
+	case "mtu":
+		// The computed MTU isn't configured, report it on the attribute enabling it.
+		if m.AutoMTU.ValueBool() {
+			return path.Root("auto_mtu"), true
+		}
+
+		if m.BridgeMTU.ValueBool() {
+			return path.Root("inherit_bridge_mtu"), true
+		}
+
+		if m.Jumbo.ValueBool() {
+			return path.Root("jumbo"), true
+		}
+
+		return path.Root(param), true
+	case "nodes", "ipam", "dns", "reversedns", "dnszone":
+		return path.Root(param), true
+	}
+
+	block := m.zoneType()
+
+	if attribute, ok := zoneBlockParams[block][param]; ok {
+		return path.Root(block).AtName(attribute), true
+	}
+
+	return path.Empty(), false
+}
+
+// zoneType returns the type of the zone from its configured block, which is also the name of the block,
+// or an empty string if no block is configured.
+func (m *sdnZoneResourceModel) zoneType() string {
+	switch {
+	case m.Simple != nil:
+		return "simple"
+	case m.VLAN != nil:
+		return "vlan"
+	case m.VXLAN != nil:
+		return "vxlan"
+	case m.QinQ != nil:
+		return "qinq"
+	case m.EVPN != nil:
+		return "evpn"
+	}
+
+	return ""
 }
 
 // RemoveAllAttributes resets all attributes except the name.
@@ -74,16 +196,97 @@ func (m *sdnZoneResourceModel) RemoveAllAttributes() {
 	*m = sdnZoneResourceModel{
 		Name:  m.Name,
 		Nodes: types.ListNull(types.StringType),
+		VNets: types.ListNull(types.StringType),
 	}
 }
 
+// resolveUnknowns sets the attributes computed by Proxmox that are still unknown to null,
+// so that a planned model can be stored as state without reading it back from the API.
+func (m *sdnZoneResourceModel) resolveUnknowns() {
+	if m.Type.IsUnknown() {
+		m.Type = types.StringNull()
+	}
+
+	if m.VNets.IsUnknown() {
+		m.VNets = types.ListNull(types.StringType)
+	}
+
+	if m.ComputedMTU.IsUnknown() {
+		m.ComputedMTU = types.Int32Null()
+	}
+
+	if m.VXLAN != nil && m.VXLAN.Port.IsUnknown() {
+		m.VXLAN.Port = types.Int32Null()
+	}
+
+	if m.EVPN != nil {
+		if m.EVPN.Mac.IsUnknown() {
+			m.EVPN.Mac = types.StringNull()
+		}
+
+		if m.EVPN.Exitnodes.IsUnknown() {
+			m.EVPN.Exitnodes = types.ListNull(types.StringType)
+		}
+
+		if m.EVPN.ExitnodesPrimary.IsUnknown() {
+			m.EVPN.ExitnodesPrimary = types.StringNull()
+		}
+
+		if m.EVPN.ExitnodesLocalRouting.IsUnknown() {
+			m.EVPN.ExitnodesLocalRouting = types.BoolNull()
+		}
+
+		if m.EVPN.AdvertiseSubnets.IsUnknown() {
+			m.EVPN.AdvertiseSubnets = types.BoolNull()
+		}
+
+		if m.EVPN.DisableArpNdSuppression.IsUnknown() {
+			m.EVPN.DisableArpNdSuppression = types.BoolNull()
+		}
+
+		if m.EVPN.RtImport.IsUnknown() {
+			m.EVPN.RtImport = types.StringNull()
+		}
+
+		if m.EVPN.Vrf.IsUnknown() {
+			m.EVPN.Vrf = types.StringNull()
+		}
+
+		if m.EVPN.ControllerASN.IsUnknown() {
+			m.EVPN.ControllerASN = types.Int64Null()
+		}
+	}
+}
+
+// noIPAM is the value of the `ipam` attribute of zones without IPAM. The attribute defaults to the
+// built-in IPAM, so a null value can't be used for it.
+const noIPAM = ""
+
+// exportIPAM converts the `ipam` attribute to the API value, which is omitted for zones without IPAM.
+func exportIPAM(ipam types.String) *string {
+	if ipam.ValueString() == noIPAM {
+		return nil
+	}
+
+	return ipam.ValueStringPointer()
+}
+
+// exportMTU converts the `mtu` attribute to the API value, which is omitted when the MTU is inherited.
+func exportMTU(mtu types.Int32) *int32 {
+	if mtu.ValueInt32() == inheritZoneMTU {
+		return nil
+	}
+
+	return mtu.ValueInt32Pointer()
+}
+
 // exportToSdnZoneBody converts the resource model to a SDN zone body for API requests.
 func (m *sdnZoneResourceModel) exportToSdnZoneBody(ctx context.Context, diags *diag.Diagnostics) *zones.SdnZoneBody {
 	result := &zones.SdnZoneBody{
 		Name:       m.Name.ValueString(),
-		Mtu:        m.MTU.ValueInt32Pointer(),
-		Nodes:      convertListToString(m.Nodes, ctx, diags),
-		Ipam:       m.IPAM.ValueStringPointer(),
+		Mtu:        exportMTU(m.MTU),
+		Nodes:      sdn.ConvertListToString(m.Nodes, ctx, diags),
+		Ipam:       exportIPAM(m.IPAM),
 		Dns:        m.DNS.ValueStringPointer(),
 		Reversedns: m.ReverseDNS.ValueStringPointer(),
 		Dnszone:    m.DNSZone.ValueStringPointer(),
@@ -100,7 +303,7 @@ func (m *sdnZoneResourceModel) exportToSdnZoneBody(ctx context.Context, diags *d
 
 	} else if m.VXLAN != nil {
 		zoneType = "vxlan"
-		result.Peers = convertListToString(m.VXLAN.Peers, ctx, diags)
+		result.Peers = sdn.ConvertListToString(m.VXLAN.Peers, ctx, diags)
 		result.VxlanPort = m.VXLAN.Port.ValueInt32Pointer()
 
 	} else if m.QinQ != nil {
@@ -112,9 +315,9 @@ func (m *sdnZoneResourceModel) exportToSdnZoneBody(ctx context.Context, diags *d
 	} else if m.EVPN != nil {
 		zoneType = "evpn"
 		result.Controller = m.EVPN.Controller.ValueStringPointer()
-		result.VrfVxlan = m.EVPN.VrfVxlan.ValueInt32Pointer()
+		result.VrfVxlan = m.EVPN.vrfVxlan().ValueInt32Pointer()
 		result.Mac = m.EVPN.Mac.ValueStringPointer()
-		result.Exitnodes = convertListToString(m.EVPN.Exitnodes, ctx, diags)
+		result.Exitnodes = sdn.ConvertListToString(m.EVPN.Exitnodes, ctx, diags)
 		result.ExitnodesPrimary = m.EVPN.ExitnodesPrimary.ValueStringPointer()
 		result.ExitnodesLocalRouting = m.EVPN.ExitnodesLocalRouting.ValueBoolPointer()
 		result.AdvertiseSubnets = m.EVPN.AdvertiseSubnets.ValueBoolPointer()
@@ -122,6 +325,13 @@ func (m *sdnZoneResourceModel) exportToSdnZoneBody(ctx context.Context, diags *d
 		result.RtImport = m.EVPN.RtImport.ValueStringPointer()
 	}
 
+	switch {
+	case m.AutoMTU.ValueBool() || m.BridgeMTU.ValueBool():
+		result.Mtu = m.ComputedMTU.ValueInt32Pointer()
+	case m.Jumbo.ValueBool():
+		result.Mtu = ptr.Ptr(int32(jumboMTU))
+	}
+
 	result.Type = &zoneType
 
 	return result
@@ -130,9 +340,35 @@ func (m *sdnZoneResourceModel) exportToSdnZoneBody(ctx context.Context, diags *d
 // importFromSdnZoneBody populates the resource model from a SDN zone body.
 func (m *sdnZoneResourceModel) importFromSdnZoneBody(ctx context.Context, body *zones.SdnZoneBody, diags *diag.Diagnostics) {
 	m.Name = types.StringValue(body.Name)
-	m.MTU = types.Int32PointerValue(body.Mtu)
-	m.Nodes = convertStringToList(body.Nodes, ctx, diags)
-	m.IPAM = types.StringPointerValue(body.Ipam)
+	m.Type = types.StringPointerValue(body.Type)
+	switch {
+	case m.AutoMTU.ValueBool() || m.BridgeMTU.ValueBool():
+		// The MTU computed by auto_mtu or inherited from the bridge is not part of the configuration, report it separately.
+		m.ComputedMTU = types.Int32PointerValue(body.Mtu)
+	case m.Jumbo.ValueBool() && ptr.Or(body.Mtu, inheritZoneMTU) == jumboMTU:
+		m.ComputedMTU = types.Int32Null()
+	default:
+		m.ComputedMTU = types.Int32Null()
+
+		// A jumbo frames zone whose MTU was changed is read back with its actual MTU and without jumbo frames.
+		if m.Jumbo.ValueBool() {
+			m.Jumbo = types.BoolValue(false)
+		}
+
+		// Proxmox doesn't store the inherited MTU, keep it when it was set explicitly.
+		if body.Mtu != nil || m.MTU.ValueInt32() != inheritZoneMTU {
+			m.MTU = types.Int32PointerValue(body.Mtu)
+		}
+	}
+
+	// Proxmox stores an empty list of nodes as no nodes, keep it so that it isn't read back as null.
+	nodes := sdn.ConvertStringToList(body.Nodes, ctx, diags)
+	if nodes.IsNull() && !m.Nodes.IsNull() && spansAllNodes(m.Nodes) {
+		nodes = m.Nodes
+	}
+
+	m.Nodes = nodes
+	m.IPAM = types.StringValue(ptr.Or(body.Ipam, noIPAM))
 	m.DNS = types.StringPointerValue(body.Dns)
 	m.ReverseDNS = types.StringPointerValue(body.Reversedns)
 	m.DNSZone = types.StringPointerValue(body.Dnszone)
@@ -147,27 +383,63 @@ func (m *sdnZoneResourceModel) importFromSdnZoneBody(ctx context.Context, body *
 			Bridge: types.StringPointerValue(body.Bridge),
 		}
 	case "vxlan":
+		// Proxmox omits the port when it's the default one, report it explicitly so that
+		// a server-side change of the port is detected as a drift.
+		port := defaultVxlanPort
+		if body.VxlanPort != nil {
+			port = *body.VxlanPort
+		}
+
 		m.VXLAN = &sdnZoneVxlanModel{
-			Peers: convertStringToList(body.Peers, ctx, diags),
-			Port:  types.Int32PointerValue(body.VxlanPort),
+			Peers: sdn.ConvertStringToList(body.Peers, ctx, diags),
+			Port:  types.Int32Value(port),
 		}
 	case "qinq":
+		// Same as the VXLAN port, the default protocol is omitted by Proxmox.
+		vlanProtocol := vlanProtocol8021Q
+		if body.VlanProtocol != nil {
+			vlanProtocol = *body.VlanProtocol
+		}
+
 		m.QinQ = &sdnZoneQinQModel{
 			Bridge:       types.StringPointerValue(body.Bridge),
 			Tag:          types.Int32PointerValue(body.Tag),
-			VlanProtocol: types.StringPointerValue(body.VlanProtocol),
+			VlanProtocol: types.StringValue(vlanProtocol),
 		}
 	case "evpn":
+		// The resolution of the controller ASN is a setting of the provider, not of the zone.
+		resolveControllerASN := types.BoolValue(false)
+		if m.EVPN != nil && !m.EVPN.ResolveControllerASN.IsNull() && !m.EVPN.ResolveControllerASN.IsUnknown() {
+			resolveControllerASN = m.EVPN.ResolveControllerASN
+		}
+
+		// So is the selection of the exit nodes, Proxmox only stores the selected nodes.
+		exitnodesAuto := types.BoolValue(false)
+		if m.EVPN != nil && !m.EVPN.ExitnodesAuto.IsNull() && !m.EVPN.ExitnodesAuto.IsUnknown() {
+			exitnodesAuto = m.EVPN.ExitnodesAuto
+		}
+
+		// The VRF VXLAN ID is read into the deprecated attribute if the configuration still uses it.
+		vrfVxlan, vfrVxlan := types.Int32PointerValue(body.VrfVxlan), types.Int32Null()
+		if m.EVPN != nil && m.EVPN.VrfVxlan.IsNull() && !m.EVPN.VfrVxlan.IsNull() {
+			vrfVxlan, vfrVxlan = vfrVxlan, vrfVxlan
+		}
+
 		m.EVPN = &sdnZoneEvpnModel{
 			Controller:              types.StringPointerValue(body.Controller),
-			VrfVxlan:                types.Int32PointerValue(body.VrfVxlan),
+			VrfVxlan:                vrfVxlan,
+			VfrVxlan:                vfrVxlan,
+			Vrf:                     types.StringValue(evpnVrfName(body.Name)),
 			Mac:                     types.StringPointerValue(body.Mac),
-			Exitnodes:               convertStringToList(body.Exitnodes, ctx, diags),
+			Exitnodes:               sdn.ConvertStringToList(body.Exitnodes, ctx, diags),
+			ExitnodesAuto:           exitnodesAuto,
 			ExitnodesPrimary:        types.StringPointerValue(body.ExitnodesPrimary),
 			ExitnodesLocalRouting:   types.BoolPointerValue(body.ExitnodesLocalRouting),
 			AdvertiseSubnets:        types.BoolPointerValue(body.AdvertiseSubnets),
 			DisableArpNdSuppression: types.BoolPointerValue(body.DisableArpNdSuppression),
 			RtImport:                types.StringPointerValue(body.RtImport),
+			ResolveControllerASN:    resolveControllerASN,
+			ControllerASN:           types.Int64Null(),
 		}
 	default:
 		diags.AddError(
@@ -269,34 +541,3 @@ func (s *sdnZoneResourceModel) exportToUpdateBody(ctx context.Context, diags *di
 
 	return body
 }
-
-// convertListToString converts a Terraform list to a comma-separated string.
-func convertListToString(list types.List, ctx context.Context, diags *diag.Diagnostics) *string {
-	if list.IsNull() || list.IsUnknown() {
-		return nil
-	}
-
-	strs := make([]types.String, 0, len(list.Elements()))
-	nodes_diags := list.ElementsAs(ctx, &strs, false)
-	diags.Append(nodes_diags...)
-
-	stringVals := make([]string, len(strs))
-	for i, v := range strs {
-		stringVals[i] = v.ValueString()
-	}
-	joined := strings.Join(stringVals, ",")
-	return &joined
-}
-
-// convertStringToList converts a comma-separated string to a Terraform list.
-func convertStringToList(value *string, ctx context.Context, diags *diag.Diagnostics) types.List {
-	if value == nil || *value == "" {
-		return types.ListNull(types.StringType)
-	}
-
-	parts := strings.Split(*value, ",")
-	list, listDiags := types.ListValueFrom(ctx, types.StringType, parts)
-	diags.Append(listDiags...)
-
-	return list
-}
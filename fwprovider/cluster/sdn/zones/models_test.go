@@ -0,0 +1,534 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestSdnZoneRequestBodies(t *testing.T) {
+	t.Parallel()
+
+	peers, d := types.ListValueFrom(t.Context(), types.StringType, []string{"10.0.0.1", "10.0.0.2"})
+	require.False(t, d.HasError())
+
+	tests := []struct {
+		name     string
+		model    sdnZoneResourceModel
+		zoneType string
+	}{
+		{"simple", sdnZoneResourceModel{Simple: &sdnZoneSimpleModel{}}, "simple"},
+		{"vlan", sdnZoneResourceModel{VLAN: &sdnZoneVlanModel{Bridge: types.StringValue("vmbr0")}}, "vlan"},
+		{"vxlan", sdnZoneResourceModel{VXLAN: &sdnZoneVxlanModel{Peers: peers, Port: types.Int32Value(4789)}}, "vxlan"},
+		{"qinq", sdnZoneResourceModel{QinQ: &sdnZoneQinQModel{Bridge: types.StringValue("vmbr0"), Tag: types.Int32Value(10)}}, "qinq"},
+		{"evpn", sdnZoneResourceModel{EVPN: &sdnZoneEvpnModel{
+			Controller: types.StringValue("evpn1"),
+			VrfVxlan:   types.Int32Value(100),
+		}}, "evpn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.model.Name = types.StringValue("zone1")
+			diags := diag.Diagnostics{}
+
+			createBody := tt.model.exportToSdnZoneBody(t.Context(), &diags)
+			require.False(t, diags.HasError())
+			require.Nil(t, createBody.Delete, "create body must never carry the delete list")
+			require.NotNil(t, createBody.Type, "create body must always carry the zone type")
+			require.Equal(t, tt.zoneType, *createBody.Type)
+
+			updateBody := tt.model.exportToUpdateBody(t.Context(), &diags)
+			require.False(t, diags.HasError())
+			require.Nil(t, updateBody.Type, "update body must never carry the zone type")
+			require.NotNil(t, updateBody.Delete)
+			require.Contains(t, *updateBody.Delete, "mtu")
+
+			// Exporting the update body must not affect the bodies exported afterwards.
+			require.Nil(t, tt.model.exportToSdnZoneBody(t.Context(), &diags).Delete)
+		})
+	}
+}
+
+func TestSdnZoneOmittedLists(t *testing.T) {
+	t.Parallel()
+
+	null := types.ListNull(types.StringType)
+
+	for _, zoneType := range []string{"simple", "vxlan", "evpn"} {
+		t.Run(zoneType, func(t *testing.T) {
+			t.Parallel()
+
+			diags := diag.Diagnostics{}
+			model := sdnZoneResourceModel{}
+
+			model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{
+				Name:      "zone1",
+				Type:      ptr.Ptr(zoneType),
+				Nodes:     ptr.Ptr(""),
+				Exitnodes: ptr.Ptr(""),
+			}, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, null, model.Nodes)
+
+			switch zoneType {
+			case "vxlan":
+				require.Equal(t, null, model.VXLAN.Peers)
+			case "evpn":
+				require.Equal(t, null, model.EVPN.Exitnodes)
+			}
+		})
+	}
+
+	model := sdnZoneResourceModel{
+		Name:  types.StringValue("zone1"),
+		Nodes: types.ListValueMust(types.StringType, nil),
+		VNets: types.ListUnknown(types.StringType),
+		EVPN:  &sdnZoneEvpnModel{Exitnodes: types.ListUnknown(types.StringType)},
+	}
+
+	// Empty lists are deleted like null ones.
+	diags := diag.Diagnostics{}
+	require.Contains(t, strings.Split(*model.exportToUpdateBody(t.Context(), &diags).Delete, ","), "nodes")
+	require.False(t, diags.HasError(), "%v", diags)
+
+	model.resolveUnknowns()
+	require.Equal(t, null, model.VNets)
+	require.Equal(t, null, model.EVPN.Exitnodes)
+
+	model.RemoveAllAttributes()
+	require.Equal(t, null, model.Nodes)
+	require.Equal(t, null, model.VNets)
+}
+
+func TestSdnZoneEmptyNodes(t *testing.T) {
+	t.Parallel()
+
+	empty, d := types.ListValueFrom(t.Context(), types.StringType, []string{})
+	require.False(t, d.HasError())
+
+	model := sdnZoneResourceModel{
+		Name:   types.StringValue("zone1"),
+		Nodes:  empty,
+		Simple: &sdnZoneSimpleModel{},
+	}
+	diags := diag.Diagnostics{}
+
+	require.True(t, spansAllNodes(model.Nodes), "an empty list of nodes must span all nodes")
+	require.Nil(t, model.exportToSdnZoneBody(t.Context(), &diags).Nodes)
+	require.Contains(t, *model.exportToUpdateBody(t.Context(), &diags).Delete, "nodes")
+
+	model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{Name: "zone1", Type: ptr.Ptr("simple")}, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, empty, model.Nodes, "the empty list must not be read back as null")
+
+	model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{
+		Name:  "zone1",
+		Type:  ptr.Ptr("simple"),
+		Nodes: ptr.Ptr("pve1"),
+	}, &diags)
+	require.False(t, diags.HasError())
+	require.False(t, spansAllNodes(model.Nodes))
+	require.Len(t, model.Nodes.Elements(), 1)
+}
+
+func TestSdnZoneImportKeepsResolveControllerASN(t *testing.T) {
+	t.Parallel()
+
+	body := &zones.SdnZoneBody{
+		Name:       "zone1",
+		Type:       ptr.Ptr("evpn"),
+		Controller: ptr.Ptr("evpn1"),
+	}
+
+	model := sdnZoneResourceModel{}
+	diags := diag.Diagnostics{}
+
+	model.importFromSdnZoneBody(t.Context(), body, &diags)
+	require.False(t, diags.HasError())
+	require.False(t, model.EVPN.ResolveControllerASN.ValueBool(), "the flag must default to false on import")
+	require.True(t, model.EVPN.ControllerASN.IsNull())
+
+	model.EVPN.ResolveControllerASN = types.BoolValue(true)
+	model.importFromSdnZoneBody(t.Context(), body, &diags)
+	require.False(t, diags.HasError())
+	require.True(t, model.EVPN.ResolveControllerASN.ValueBool(), "the flag must be kept across reads")
+}
+
+func TestSdnZoneDeprecatedVfrVxlan(t *testing.T) {
+	t.Parallel()
+
+	body := &zones.SdnZoneBody{
+		Name:       "zone1",
+		Type:       ptr.Ptr("evpn"),
+		Controller: ptr.Ptr("evpn1"),
+		VrfVxlan:   ptr.Ptr(int32(10000)),
+	}
+
+	// The VRF VXLAN ID set with the deprecated attribute is sent and read back into it.
+	model := sdnZoneResourceModel{
+		Name: types.StringValue("zone1"),
+		EVPN: &sdnZoneEvpnModel{
+			Controller: types.StringValue("evpn1"),
+			VfrVxlan:   types.Int32Value(10000),
+		},
+	}
+	diags := diag.Diagnostics{}
+
+	require.Equal(t, ptr.Ptr(int32(10000)), model.exportToSdnZoneBody(t.Context(), &diags).VrfVxlan)
+
+	model.importFromSdnZoneBody(t.Context(), body, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, types.Int32Value(10000), model.EVPN.VfrVxlan)
+	require.True(t, model.EVPN.VrfVxlan.IsNull())
+
+	// Otherwise, and on import, it's read into `vrf_vxlan`.
+	model = sdnZoneResourceModel{}
+
+	model.importFromSdnZoneBody(t.Context(), body, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, types.Int32Value(10000), model.EVPN.VrfVxlan)
+	require.True(t, model.EVPN.VfrVxlan.IsNull())
+}
+
+func TestSdnZoneImportOmittedFields(t *testing.T) {
+	t.Parallel()
+
+	// The attributes that the provider sets even when Proxmox omits them, all others must be null.
+	defaults := map[string]attr.Value{
+		"name":                        types.StringValue("zone1"),
+		"type":                        nil, // checked for each zone type
+		"ipam":                        types.StringValue(noIPAM),
+		"vxlan.port":                  types.Int32Value(defaultVxlanPort),
+		"qinq.vlan_protocol":          types.StringValue(vlanProtocol8021Q),
+		"evpn.vrf":                    types.StringValue(evpnVrfName("zone1")),
+		"evpn.exitnodes_auto":         types.BoolValue(false),
+		"evpn.resolve_controller_asn": types.BoolValue(false),
+	}
+
+	// checkAttributes checks the attributes of a model or of a zone block, prefixing their names with the block.
+	checkAttributes := func(t *testing.T, prefix string, value reflect.Value) {
+		t.Helper()
+
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			name := prefix + field.Tag.Get("tfsdk")
+
+			attribute, ok := value.Field(i).Interface().(attr.Value)
+			if !ok {
+				continue
+			}
+
+			if expected, ok := defaults[name]; ok {
+				if expected != nil {
+					require.Equal(t, expected, attribute, name)
+				}
+
+				continue
+			}
+
+			require.True(t, attribute.IsNull(), "%s must be null, got %s", name, attribute)
+		}
+	}
+
+	for _, zoneType := range []string{"simple", "vlan", "vxlan", "qinq", "evpn"} {
+		t.Run(zoneType, func(t *testing.T) {
+			t.Parallel()
+
+			model := sdnZoneResourceModel{}
+			diags := diag.Diagnostics{}
+
+			model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{Name: "zone1", Type: ptr.Ptr(zoneType)}, &diags)
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, types.StringValue(zoneType), model.Type)
+
+			checkAttributes(t, "", reflect.ValueOf(model))
+
+			var block any
+
+			switch zoneType {
+			case "simple":
+				block = model.Simple
+			case "vlan":
+				block = model.VLAN
+			case "vxlan":
+				block = model.VXLAN
+			case "qinq":
+				block = model.QinQ
+			case "evpn":
+				block = model.EVPN
+			}
+
+			require.NotNil(t, block)
+			checkAttributes(t, zoneType+".", reflect.ValueOf(block).Elem())
+		})
+	}
+}
+
+func TestSdnZoneVxlanPort(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	attribute, d := schemaResp.Schema.AttributeAtPath(ctx, path.Root("vxlan").AtName("port"))
+	require.False(t, d.HasError())
+
+	// The port planned for a zone without one configured.
+	defaultResp := &defaults.Int32Response{}
+	attribute.(schema.Int32Attribute).Default.DefaultInt32(ctx, defaults.Int32Request{}, defaultResp)
+
+	tests := []struct {
+		name string
+		port *int32
+		// planned is whether the read port matches the plan of an unset port, i.e. the next plan is empty.
+		planned bool
+	}{
+		{"omitted by Proxmox", nil, true},
+		{"default returned by Proxmox", ptr.Ptr(defaultVxlanPort), true},
+		{"changed outside of Terraform", ptr.Ptr(int32(4790)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := sdnZoneResourceModel{}
+			diags := diag.Diagnostics{}
+
+			model.importFromSdnZoneBody(ctx, &zones.SdnZoneBody{
+				Name:      "zone1",
+				Type:      ptr.Ptr("vxlan"),
+				Peers:     ptr.Ptr("10.0.0.1,10.0.0.2"),
+				VxlanPort: tt.port,
+			}, &diags)
+			require.False(t, diags.HasError())
+			require.Equal(t, ptr.Or(tt.port, defaultVxlanPort), model.VXLAN.Port.ValueInt32())
+			require.Equal(t, tt.planned, model.VXLAN.Port.Equal(defaultResp.PlanValue))
+		})
+	}
+}
+
+func TestSdnZoneQinQVlanProtocol(t *testing.T) {
+	t.Parallel()
+
+	for _, protocol := range []string{vlanProtocol8021Q, vlanProtocol8021AD} {
+		t.Run(protocol, func(t *testing.T) {
+			t.Parallel()
+
+			model := sdnZoneResourceModel{
+				Name: types.StringValue("zone1"),
+				QinQ: &sdnZoneQinQModel{
+					Bridge:       types.StringValue("vmbr0"),
+					Tag:          types.Int32Value(100),
+					VlanProtocol: types.StringValue(protocol),
+				},
+			}
+			diags := diag.Diagnostics{}
+
+			values, err := query.Values(model.exportToSdnZoneBody(t.Context(), &diags))
+			require.NoError(t, err)
+			require.False(t, diags.HasError())
+			require.Equal(t, protocol, values.Get("vlan-protocol"))
+			require.Equal(t, "100", values.Get("tag"))
+
+			model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{
+				Name:         "zone1",
+				Type:         ptr.Ptr("qinq"),
+				Bridge:       ptr.Ptr("vmbr0"),
+				Tag:          ptr.Ptr(int32(100)),
+				VlanProtocol: ptr.Ptr(protocol),
+			}, &diags)
+			require.False(t, diags.HasError())
+			require.Equal(t, protocol, model.QinQ.VlanProtocol.ValueString())
+		})
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		model := sdnZoneResourceModel{}
+		diags := diag.Diagnostics{}
+
+		model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{
+			Name:   "zone1",
+			Type:   ptr.Ptr("qinq"),
+			Bridge: ptr.Ptr("vmbr0"),
+			Tag:    ptr.Ptr(int32(100)),
+		}, &diags)
+		require.False(t, diags.HasError())
+		require.Equal(t, vlanProtocol8021Q, model.QinQ.VlanProtocol.ValueString())
+	})
+}
+
+func TestSdnZoneIPAM(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ipam *string
+	}{
+		{"default IPAM", ptr.Ptr("pve")},
+		{"custom IPAM", ptr.Ptr("netbox")},
+		{"no IPAM", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := sdnZoneResourceModel{}
+			diags := diag.Diagnostics{}
+
+			model.importFromSdnZoneBody(t.Context(), &zones.SdnZoneBody{
+				Name: "zone1",
+				Type: ptr.Ptr("simple"),
+				Ipam: tt.ipam,
+			}, &diags)
+			require.False(t, diags.HasError())
+			require.False(t, model.IPAM.IsNull(), "the IPAM must never be read back as null")
+			require.Equal(t, ptr.Or(tt.ipam, ""), model.IPAM.ValueString())
+
+			model.Nodes = types.ListNull(types.StringType)
+
+			require.Equal(t, tt.ipam, model.exportToSdnZoneBody(t.Context(), &diags).Ipam)
+
+			updateBody := model.exportToUpdateBody(t.Context(), &diags)
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.ipam == nil, slices.Contains(strings.Split(*updateBody.Delete, ","), "ipam"))
+		})
+	}
+}
+
+func TestSdnZoneInheritedMTU(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mtu      types.Int32
+		apiMTU   *int32
+		stateMTU types.Int32
+	}{
+		{"explicit MTU", types.Int32Value(1450), ptr.Ptr(int32(1450)), types.Int32Value(1450)},
+		{"inherited MTU", types.Int32Value(inheritZoneMTU), nil, types.Int32Value(inheritZoneMTU)},
+		{"omitted MTU", types.Int32Null(), nil, types.Int32Null()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			model := sdnZoneResourceModel{
+				Name:   types.StringValue("zone1"),
+				MTU:    tt.mtu,
+				Nodes:  types.ListNull(types.StringType),
+				Simple: &sdnZoneSimpleModel{},
+			}
+			diags := diag.Diagnostics{}
+
+			body := model.exportToSdnZoneBody(t.Context(), &diags)
+			require.Equal(t, tt.apiMTU, body.Mtu)
+
+			updateBody := model.exportToUpdateBody(t.Context(), &diags)
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.apiMTU == nil, slices.Contains(strings.Split(*updateBody.Delete, ","), "mtu"),
+				"an inherited MTU must clear the previously set one")
+
+			model.importFromSdnZoneBody(t.Context(), body, &diags)
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.stateMTU, model.MTU)
+		})
+	}
+}
+
+func TestSdnZoneJumboMTU(t *testing.T) {
+	t.Parallel()
+
+	model := sdnZoneResourceModel{
+		Name:   types.StringValue("zone1"),
+		Jumbo:  types.BoolValue(true),
+		Nodes:  types.ListNull(types.StringType),
+		Simple: &sdnZoneSimpleModel{},
+	}
+	diags := diag.Diagnostics{}
+
+	body := model.exportToSdnZoneBody(t.Context(), &diags)
+	require.Equal(t, ptr.Ptr(int32(jumboMTU)), body.Mtu)
+
+	updateBody := model.exportToUpdateBody(t.Context(), &diags)
+	require.False(t, diags.HasError())
+	require.NotContains(t, strings.Split(*updateBody.Delete, ","), "mtu")
+
+	model.importFromSdnZoneBody(t.Context(), body, &diags)
+	require.False(t, diags.HasError())
+	require.True(t, model.Jumbo.ValueBool())
+	require.True(t, model.MTU.IsNull(), "the MTU set by jumbo must not be reported as configured")
+
+	// An MTU changed outside of Terraform disables jumbo frames in the state, so that the next apply restores them.
+	body.Mtu = ptr.Ptr(int32(1500))
+
+	model.importFromSdnZoneBody(t.Context(), body, &diags)
+	require.False(t, diags.HasError())
+	require.False(t, model.Jumbo.ValueBool())
+	require.Equal(t, types.Int32Value(1500), model.MTU)
+}
+
+func TestAttributePath(t *testing.T) {
+	t.Parallel()
+
+	vxlan := &sdnZoneResourceModel{VXLAN: &sdnZoneVxlanModel{}}
+	autoMTU := &sdnZoneResourceModel{AutoMTU: types.BoolValue(true), EVPN: &sdnZoneEvpnModel{}}
+	jumbo := &sdnZoneResourceModel{Jumbo: types.BoolValue(true), VLAN: &sdnZoneVlanModel{}}
+	bridgeMTU := &sdnZoneResourceModel{BridgeMTU: types.BoolValue(true), VLAN: &sdnZoneVlanModel{}}
+
+	tests := []struct {
+		name  string
+		model *sdnZoneResourceModel
+		param string
+		path  path.Path
+		ok    bool
+	}{
+		{"base attribute", vxlan, "nodes", path.Root("nodes"), true},
+		{"block attribute", vxlan, "vxlan-port", path.Root("vxlan").AtName("port"), true},
+		{"attribute of another block", vxlan, "vrf-vxlan", path.Empty(), false},
+		{"computed MTU", autoMTU, "mtu", path.Root("auto_mtu"), true},
+		{"jumbo MTU", jumbo, "mtu", path.Root("jumbo"), true},
+		{"bridge MTU", bridgeMTU, "mtu", path.Root("inherit_bridge_mtu"), true},
+		{"unknown parameter", vxlan, "digest", path.Empty(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, ok := tt.model.attributePath(tt.param)
+			require.Equal(t, tt.ok, ok)
+			require.True(t, tt.path.Equal(p), "%s", p)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	proxmoxnodes "github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// vxlanOverhead is the size of the VXLAN encapsulation: the outer Ethernet, IPv4, UDP and VXLAN headers.
+	vxlanOverhead = 50
+	// defaultInterfaceMTU is the MTU of the network interfaces that don't set one.
+	defaultInterfaceMTU = 1500
+	// jumboMTU is the MTU of the zones using jumbo frames.
+	jumboMTU = 9000
+)
+
+// planComputedMTU sets the computed MTU of the plan to null when the MTU isn't computed, so that
+// it isn't reported as known after apply for the other zones.
+func (r *sdnZoneResource) planComputedMTU(ctx context.Context, resp *resource.ModifyPlanResponse) {
+	if resp.Plan.Raw.IsNull() {
+		return
+	}
+
+	var autoMTU, bridgeMTU types.Bool
+
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("auto_mtu"), &autoMTU)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("inherit_bridge_mtu"), &bridgeMTU)...)
+
+	if resp.Diagnostics.HasError() || autoMTU.IsUnknown() || autoMTU.ValueBool() ||
+		bridgeMTU.IsUnknown() || bridgeMTU.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("computed_mtu"), types.Int32Null())...)
+}
+
+// resolveAutoMTU computes the MTU of a zone with `auto_mtu` from the MTU of the interface of the default route
+// of its nodes, the lowest one minus the VXLAN overhead, and sets it as the computed MTU of the model.
+func (r *sdnZoneResource) resolveAutoMTU(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	if !model.AutoMTU.ValueBool() {
+		model.ComputedMTU = types.Int32Null()
+		return
+	}
+
+	nodeNames, err := r.zoneNodeNames(ctx, model.Nodes, diags)
+	if err != nil {
+		diags.AddError(
+			"Unable to Compute SDN Zone MTU",
+			fmt.Sprintf("Failed to list cluster nodes: %s", err),
+		)
+		return
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	lowest := 0
+
+	for _, nodeName := range nodeNames {
+		ifaces, err := r.client.Node(nodeName).ListNetworkInterfaces(ctx)
+		if err != nil {
+			diags.AddError(
+				"Unable to Compute SDN Zone MTU",
+				fmt.Sprintf("Failed to list network interfaces of node %s: %s", nodeName, err),
+			)
+			return
+		}
+
+		mtu, ok := defaultRouteMTU(ifaces)
+		if !ok {
+			diags.AddError(
+				"Unable to Compute SDN Zone MTU",
+				fmt.Sprintf("Node %s has no network interface with a default gateway to compute the MTU from, "+
+					"set `mtu` instead of `auto_mtu`", nodeName),
+			)
+			return
+		}
+
+		if lowest == 0 || mtu < lowest {
+			lowest = mtu
+		}
+	}
+
+	if lowest == 0 {
+		diags.AddError(
+			"Unable to Compute SDN Zone MTU",
+			fmt.Sprintf("SDN zone %s has no nodes to compute the MTU from", model.Name.ValueString()),
+		)
+		return
+	}
+
+	mtu := lowest - vxlanOverhead
+	if mtu < minZoneMTU || mtu > maxZoneMTU {
+		diags.AddError(
+			"Invalid SDN Zone MTU",
+			fmt.Sprintf("The MTU %d computed for SDN zone %s from the interface MTU %d is not between %d and %d",
+				mtu, model.Name.ValueString(), lowest, minZoneMTU, maxZoneMTU),
+		)
+		return
+	}
+
+	model.ComputedMTU = types.Int32Value(int32(mtu))
+}
+
+// resolveBridgeMTU reads the MTU of the bridge of a VLAN or QinQ zone with `inherit_bridge_mtu` on its nodes,
+// and sets the lowest one as the computed MTU of the model. Unlike auto_mtu, there is no encapsulation overhead.
+func (r *sdnZoneResource) resolveBridgeMTU(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	if !model.BridgeMTU.ValueBool() {
+		return
+	}
+
+	var bridge string
+
+	switch {
+	case model.VLAN != nil:
+		bridge = model.VLAN.Bridge.ValueString()
+	case model.QinQ != nil:
+		bridge = model.QinQ.Bridge.ValueString()
+	}
+
+	nodeNames, err := r.zoneNodeNames(ctx, model.Nodes, diags)
+	if err != nil {
+		diags.AddError(
+			"Unable to Inherit SDN Zone MTU",
+			fmt.Sprintf("Failed to list cluster nodes: %s", err),
+		)
+		return
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	lowest := 0
+
+	for _, nodeName := range nodeNames {
+		ifaces, err := r.client.Node(nodeName).ListNetworkInterfaces(ctx)
+		if err != nil {
+			diags.AddError(
+				"Unable to Inherit SDN Zone MTU",
+				fmt.Sprintf("Failed to list network interfaces of node %s: %s", nodeName, err),
+			)
+			return
+		}
+
+		iface := findBridge(ifaces, bridge)
+		if iface == nil {
+			diags.AddError(
+				"Unable to Inherit SDN Zone MTU",
+				fmt.Sprintf("Bridge %s does not exist on node %s", bridge, nodeName),
+			)
+			return
+		}
+
+		if iface.MTU != nil {
+			if _, err := strconv.Atoi(*iface.MTU); err != nil {
+				diags.AddError(
+					"Unable to Inherit SDN Zone MTU",
+					fmt.Sprintf("Bridge %s of node %s has an invalid MTU %q, set `mtu` instead of `inherit_bridge_mtu`",
+						bridge, nodeName, *iface.MTU),
+				)
+				return
+			}
+		}
+
+		if mtu := interfaceMTU(iface); lowest == 0 || mtu < lowest {
+			lowest = mtu
+		}
+	}
+
+	if lowest == 0 {
+		diags.AddError(
+			"Unable to Inherit SDN Zone MTU",
+			fmt.Sprintf("SDN zone %s has no nodes to read the MTU of bridge %s from", model.Name.ValueString(), bridge),
+		)
+		return
+	}
+
+	if lowest < minZoneMTU || lowest > maxZoneMTU {
+		diags.AddError(
+			"Invalid SDN Zone MTU",
+			fmt.Sprintf("The MTU %d of bridge %s is not between %d and %d, set `mtu` instead of `inherit_bridge_mtu`",
+				lowest, bridge, minZoneMTU, maxZoneMTU),
+		)
+		return
+	}
+
+	model.ComputedMTU = types.Int32Value(int32(lowest))
+}
+
+// defaultRouteMTU returns the MTU of the interface holding the IPv4 default gateway of a node,
+// or the IPv6 one if there is no IPv4 default gateway.
+func defaultRouteMTU(ifaces []*proxmoxnodes.NetworkInterfaceListResponseData) (int, bool) {
+	var gateway6 *proxmoxnodes.NetworkInterfaceListResponseData
+
+	for _, iface := range ifaces {
+		if iface.Gateway != nil && *iface.Gateway != "" {
+			return interfaceMTU(iface), true
+		}
+
+		if gateway6 == nil && iface.Gateway6 != nil && *iface.Gateway6 != "" {
+			gateway6 = iface
+		}
+	}
+
+	if gateway6 != nil {
+		return interfaceMTU(gateway6), true
+	}
+
+	return 0, false
+}
+
+// interfaceMTU returns the MTU of a network interface, which is only reported when it's set explicitly.
+func interfaceMTU(iface *proxmoxnodes.NetworkInterfaceListResponseData) int {
+	if iface.MTU != nil {
+		if mtu, err := strconv.Atoi(*iface.MTU); err == nil {
+			return mtu
+		}
+	}
+
+	return defaultInterfaceMTU
+}
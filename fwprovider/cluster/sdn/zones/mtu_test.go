@@ -0,0 +1,159 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAutoMTU(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/nodes":
+			_, _ = w.Write([]byte(`{"data":[{"node":"pve1","status":"online"},{"node":"pve2","status":"online"},` +
+				`{"node":"pve3","status":"offline"}]}`))
+		case "/api2/json/nodes/pve1/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr1","type":"bridge","priority":1,"mtu":"1400"},` +
+				`{"iface":"vmbr0","type":"bridge","priority":2,"mtu":"9000","gateway":"10.0.0.1"}]}`))
+		case "/api2/json/nodes/pve2/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","priority":1,"gateway":"10.0.0.1"}]}`))
+		case "/api2/json/nodes/pve4/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","priority":1,"mtu":"9000",` +
+				`"gateway6":"fd00::1"}]}`))
+		case "/api2/json/nodes/pve5/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","priority":1,"mtu":"9000"}]}`))
+		case "/api2/json/nodes/pve6/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","priority":1,"mtu":"100",` +
+				`"gateway":"10.0.0.1"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	stringList := func(values ...string) types.List {
+		list, d := types.ListValueFrom(t.Context(), types.StringType, values)
+		require.False(t, d.HasError())
+
+		return list
+	}
+
+	tests := []struct {
+		name    string
+		autoMTU bool
+		nodes   types.List
+		mtu     types.Int32
+		errors  int
+	}{
+		{"disabled", false, stringList("pve1"), types.Int32Null(), 0},
+		{"jumbo frames", true, stringList("pve1"), types.Int32Value(8950), 0},
+		{"lowest MTU of the nodes", true, stringList("pve1", "pve2"), types.Int32Value(1450), 0},
+		{"online nodes of the cluster", true, types.ListNull(types.StringType), types.Int32Value(1450), 0},
+		{"IPv6 default gateway", true, stringList("pve4"), types.Int32Value(8950), 0},
+		{"no default gateway", true, stringList("pve1", "pve5"), types.Int32Null(), 1},
+		{"MTU out of range", true, stringList("pve6"), types.Int32Null(), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client}
+			model := sdnZoneResourceModel{
+				Name:        types.StringValue("zone1"),
+				Nodes:       tt.nodes,
+				AutoMTU:     types.BoolValue(tt.autoMTU),
+				ComputedMTU: types.Int32Null(),
+			}
+			diags := diag.Diagnostics{}
+
+			r.resolveAutoMTU(t.Context(), &model, &diags)
+			require.Equal(t, tt.errors, diags.ErrorsCount(), "%v", diags)
+			require.Equal(t, tt.mtu, model.ComputedMTU)
+
+			if tt.errors == 0 && tt.autoMTU {
+				body := model.exportToSdnZoneBody(t.Context(), &diags)
+				require.NotNil(t, body.Mtu)
+				require.Equal(t, tt.mtu.ValueInt32(), *body.Mtu)
+			}
+		})
+	}
+}
+
+func TestResolveBridgeMTU(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api2/json/nodes":
+			_, _ = w.Write([]byte(`{"data":[{"node":"pve1","status":"online"},{"node":"pve2","status":"online"}]}`))
+		case "/api2/json/nodes/pve1/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge","mtu":"9000"},` +
+				`{"iface":"vmbr1","type":"bridge","mtu":"auto"},{"iface":"eth0","type":"eth","mtu":"1400"}]}`))
+		case "/api2/json/nodes/pve2/network":
+			_, _ = w.Write([]byte(`{"data":[{"iface":"vmbr0","type":"bridge"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	})
+
+	stringList := func(values ...string) types.List {
+		list, d := types.ListValueFrom(t.Context(), types.StringType, values)
+		require.False(t, d.HasError())
+
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		bridgeMTU bool
+		bridge    string
+		nodes     types.List
+		mtu       types.Int32
+		errors    int
+	}{
+		{"disabled", false, "vmbr0", stringList("pve1"), types.Int32Null(), 0},
+		{"bridge MTU", true, "vmbr0", stringList("pve1"), types.Int32Value(9000), 0},
+		{"default bridge MTU", true, "vmbr0", stringList("pve2"), types.Int32Value(1500), 0},
+		{"lowest MTU of the cluster", true, "vmbr0", types.ListNull(types.StringType), types.Int32Value(1500), 0},
+		{"missing bridge", true, "vmbr2", stringList("pve1"), types.Int32Null(), 1},
+		{"not a bridge", true, "eth0", stringList("pve1"), types.Int32Null(), 1},
+		{"invalid MTU", true, "vmbr1", stringList("pve1"), types.Int32Null(), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: client}
+			model := sdnZoneResourceModel{
+				Name:        types.StringValue("zone1"),
+				Nodes:       tt.nodes,
+				BridgeMTU:   types.BoolValue(tt.bridgeMTU),
+				ComputedMTU: types.Int32Null(),
+				VLAN:        &sdnZoneVlanModel{Bridge: types.StringValue(tt.bridge)},
+			}
+			diags := diag.Diagnostics{}
+
+			r.resolveBridgeMTU(t.Context(), &model, &diags)
+			require.Equal(t, tt.errors, diags.ErrorsCount(), "%v", diags)
+			require.Equal(t, tt.mtu, model.ComputedMTU)
+
+			if tt.errors == 0 && tt.bridgeMTU {
+				body := model.exportToSdnZoneBody(t.Context(), &diags)
+				require.NotNil(t, body.Mtu)
+				require.Equal(t, tt.mtu.ValueInt32(), *body.Mtu)
+			}
+		})
+	}
+}
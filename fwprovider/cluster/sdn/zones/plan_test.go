@@ -0,0 +1,140 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+)
+
+func TestModifyPlanDefaultIPAM(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tests := []struct {
+		name        string
+		defaultIPAM string
+		configIPAM  types.String
+		expected    string
+	}{
+		{"built-in default", "", types.StringNull(), ipams.DefaultIPAM},
+		{"provider default", "netbox", types.StringNull(), "netbox"},
+		{"explicit IPAM wins", "netbox", types.StringValue(ipams.DefaultIPAM), ipams.DefaultIPAM},
+		{"explicit no IPAM wins", "netbox", types.StringValue(noIPAM), noIPAM},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := func(ipam types.String) tfsdk.State {
+				s := tfsdk.State{Schema: schemaResp.Schema}
+				require.False(t, s.Set(ctx, &sdnZoneResourceModel{
+					Name:   types.StringValue("zone1"),
+					IPAM:   ipam,
+					Nodes:  types.ListNull(types.StringType),
+					VNets:  types.ListNull(types.StringType),
+					Simple: &sdnZoneSimpleModel{},
+				}).HasError())
+
+				return s
+			}
+
+			config := state(tt.configIPAM)
+			plannedIPAM := tt.configIPAM
+
+			if plannedIPAM.IsNull() {
+				plannedIPAM = types.StringValue(ipams.DefaultIPAM)
+			}
+
+			plan := state(plannedIPAM)
+
+			r := &sdnZoneResource{defaultIPAM: tt.defaultIPAM}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config(config),
+				Plan:   tfsdk.Plan(plan),
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var ipam types.String
+			require.False(t, resp.Plan.GetAttribute(ctx, path.Root("ipam"), &ipam).HasError())
+			require.Equal(t, tt.expected, ipam.ValueString())
+		})
+	}
+}
+
+func TestModifyPlanIPAMReplacement(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tests := []struct {
+		name            string
+		requiresReplace bool
+		plannedIPAM     string
+		replace         bool
+	}{
+		{"in-place change", false, "netbox", false},
+		{"replacing change", true, "netbox", true},
+		{"unchanged IPAM", true, ipams.DefaultIPAM, false},
+		{"IPAM removed", true, noIPAM, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := func(ipam string) tfsdk.State {
+				s := tfsdk.State{Schema: schemaResp.Schema}
+				require.False(t, s.Set(ctx, &sdnZoneResourceModel{
+					Name:        types.StringValue("zone1"),
+					IPAM:        types.StringValue(ipam),
+					IPAMReplace: types.BoolValue(tt.requiresReplace),
+					Nodes:       types.ListNull(types.StringType),
+					VNets:       types.ListNull(types.StringType),
+					Simple:      &sdnZoneSimpleModel{},
+				}).HasError())
+
+				return s
+			}
+
+			plan := state(tt.plannedIPAM)
+
+			r := &sdnZoneResource{}
+			resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config(plan),
+				Plan:   tfsdk.Plan(plan),
+				State:  state(ipams.DefaultIPAM),
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.replace {
+				require.Equal(t, path.Paths{path.Root("ipam")}, resp.RequiresReplace)
+			} else {
+				require.Empty(t, resp.RequiresReplace)
+			}
+		})
+	}
+}
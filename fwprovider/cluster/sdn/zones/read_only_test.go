@@ -0,0 +1,103 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyZone(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		http.Error(w, "", http.StatusInternalServerError)
+	})
+
+	r := &sdnZoneResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	zone := func(readOnly bool, bridge string) tfsdk.State {
+		s := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, s.Set(ctx, &sdnZoneResourceModel{
+			Name:      types.StringValue("zone1"),
+			Type:      types.StringValue("vlan"),
+			Nodes:     types.ListNull(types.StringType),
+			ListVNets: types.BoolValue(false),
+			VNets:     types.ListNull(types.StringType),
+			ReadOnly:  types.BoolValue(readOnly),
+			VLAN:      &sdnZoneVlanModel{Bridge: types.StringValue(bridge)},
+		}).HasError())
+
+		return s
+	}
+
+	t.Run("changes are dropped from the plan", func(t *testing.T) {
+		t.Parallel()
+
+		state := zone(true, "vmbr0")
+		plan := zone(true, "vmbr1")
+
+		resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config: tfsdk.Config(plan),
+			Plan:   tfsdk.Plan(plan),
+			State:  state,
+		}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+
+		var bridge types.String
+		require.False(t, resp.Plan.GetAttribute(ctx, path.Root("vlan").AtName("bridge"), &bridge).HasError())
+		require.Equal(t, "vmbr0", bridge.ValueString())
+	})
+
+	t.Run("read-only flag is updated", func(t *testing.T) {
+		t.Parallel()
+
+		state := zone(false, "vmbr0")
+		plan := zone(true, "vmbr1")
+
+		modifyResp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config: tfsdk.Config(plan),
+			Plan:   tfsdk.Plan(plan),
+			State:  state,
+		}, modifyResp)
+		require.False(t, modifyResp.Diagnostics.HasError(), "%v", modifyResp.Diagnostics)
+
+		resp := &resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{Plan: modifyResp.Plan, State: state}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var model sdnZoneResourceModel
+		require.False(t, resp.State.Get(ctx, &model).HasError())
+		require.True(t, model.ReadOnly.ValueBool())
+		require.Equal(t, "vmbr0", model.VLAN.Bridge.ValueString())
+	})
+
+	t.Run("zone is not deleted", func(t *testing.T) {
+		t.Parallel()
+
+		resp := &resource.DeleteResponse{}
+		r.Delete(ctx, resource.DeleteRequest{State: zone(true, "vmbr0")}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		require.Equal(t, 1, resp.Diagnostics.WarningsCount())
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNotFound(t *testing.T) {
+	t.Parallel()
+
+	notFound := func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "", http.StatusNotFound)
+	}
+	// Proxmox reports a missing zone with an internal server error naming the zone.
+	doesNotExist := func(w http.ResponseWriter, _ *http.Request) {
+		writeStatus(t, w, http.StatusInternalServerError, "sdn zone object ID 'zone1' does not exist")
+	}
+
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		quietNotFound bool
+		warnings      int
+	}{
+		{"warning by default", notFound, false, 1},
+		{"quiet", notFound, true, 0},
+		{"does not exist", doesNotExist, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &sdnZoneResource{client: newTestClient(t, tt.handler), quietNotFound: tt.quietNotFound}
+			model := sdnZoneResourceModel{
+				Name:   types.StringValue("zone1"),
+				Type:   types.StringValue("simple"),
+				MTU:    types.Int32Value(1450),
+				Simple: &sdnZoneSimpleModel{},
+			}
+			diags := diag.Diagnostics{}
+
+			require.False(t, r.read(t.Context(), &model, &diags), "the zone must be reported as missing")
+			require.False(t, diags.HasError(), "%v", diags)
+			require.Equal(t, tt.warnings, diags.WarningsCount())
+			require.Nil(t, model.Simple, "the zone must be removed from the state")
+			require.True(t, model.Type.IsNull())
+			require.True(t, model.MTU.IsNull())
+		})
+	}
+}
+
+func TestReadRemovesMissingZone(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	r := &sdnZoneResource{client: newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "", http.StatusNotFound)
+	})}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &sdnZoneResourceModel{
+		Name:      types.StringValue("zone1"),
+		Type:      types.StringValue("simple"),
+		Nodes:     types.ListNull(types.StringType),
+		ListVNets: types.BoolValue(false),
+		VNets:     types.ListNull(types.StringType),
+		ReadOnly:  types.BoolValue(false),
+		Simple:    &sdnZoneSimpleModel{},
+	}).HasError())
+
+	// The zone is dropped from the state, so that the next plan creates it rather than replacing it.
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.True(t, resp.State.Raw.IsNull())
+}
@@ -0,0 +1,373 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// minZoneMTU is the minimum MTU of an IPv4 link (RFC 791).
+	minZoneMTU = 68
+	// maxZoneMTU is the maximum MTU supported by the Linux network interfaces.
+	maxZoneMTU = 65535
+	// inheritZoneMTU is the MTU value meaning that the zone inherits the MTU of the system,
+	// it's never sent to Proxmox.
+	inheritZoneMTU = 0
+
+	// minVlanTag and maxVlanTag bound the usable VLAN IDs, 0 and 4095 are reserved by IEEE 802.1Q.
+	minVlanTag = 1
+	maxVlanTag = 4094
+
+	// minVxlanID and maxVxlanID bound the VXLAN network identifiers, which are 24-bit numbers (RFC 7348).
+	minVxlanID = 1
+	maxVxlanID = 1<<24 - 1
+)
+
+// reservedZoneNames lists the SDN zone names used by Proxmox for its built-in zones.
+var reservedZoneNames = []string{"localnetwork"}
+
+// reservedZoneNameValidator rejects the SDN zone names reserved by Proxmox.
+func reservedZoneNameValidator() validator.String {
+	return validators.NewParseValidator(
+		func(s string) (string, error) {
+			if slices.Contains(reservedZoneNames, s) {
+				return s, fmt.Errorf("%q is a reserved SDN zone name", s)
+			}
+
+			return s, nil
+		},
+		fmt.Sprintf(
+			"must not be one of the SDN zone names reserved by Proxmox for built-in zones: %s",
+			strings.Join(reservedZoneNames, ", "),
+		),
+	)
+}
+
+// routeTargetListValidator validates a comma-separated list of BGP route targets.
+func routeTargetListValidator() validator.String {
+	return validators.NewParseValidator(
+		func(s string) ([]string, error) {
+			targets := strings.Split(s, ",")
+			for _, rt := range targets {
+				if err := parseRouteTarget(strings.TrimSpace(rt)); err != nil {
+					return nil, err
+				}
+			}
+
+			return targets, nil
+		},
+		"must be a comma-separated list of route targets in the `<ASN>:<number>`, `<IPv4>:<number>` "+
+			"or `[<IPv6>]:<number>` format",
+	)
+}
+
+// parseRouteTarget validates a single route target. The administrator part is either an ASN,
+// an IPv4 address or a bracketed IPv6 address, and the size of the assigned number depends on it,
+// following the BGP extended community formats (RFC 4360, RFC 5668 and RFC 5701).
+func parseRouteTarget(rt string) error {
+	sep := strings.LastIndex(rt, ":")
+	if sep <= 0 || sep == len(rt)-1 {
+		return fmt.Errorf("route target %q must be in the `<administrator>:<number>` format", rt)
+	}
+
+	admin, assigned := rt[:sep], rt[sep+1:]
+
+	number, err := strconv.ParseUint(assigned, 10, 32)
+	if err != nil {
+		return fmt.Errorf("route target %q has an invalid assigned number: %w", rt, err)
+	}
+
+	var maxNumber uint64
+
+	switch {
+	case strings.HasPrefix(admin, "[") && strings.HasSuffix(admin, "]"):
+		addr, err := netip.ParseAddr(admin[1 : len(admin)-1])
+		if err != nil || !addr.Is6() {
+			return fmt.Errorf("route target %q has an invalid IPv6 administrator", rt)
+		}
+
+		maxNumber = math.MaxUint16
+	case strings.Contains(admin, "."):
+		addr, err := netip.ParseAddr(admin)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("route target %q has an invalid IPv4 administrator", rt)
+		}
+
+		maxNumber = math.MaxUint16
+	default:
+		asn, err := strconv.ParseUint(admin, 10, 32)
+		if err != nil {
+			return fmt.Errorf("route target %q has an invalid ASN administrator: %w", rt, err)
+		}
+
+		maxNumber = math.MaxUint32
+		if asn > math.MaxUint16 {
+			maxNumber = math.MaxUint16
+		}
+	}
+
+	if number > maxNumber {
+		return fmt.Errorf("route target %q has an assigned number larger than %d", rt, maxNumber)
+	}
+
+	return nil
+}
+
+// dnsLabelRegex matches a single label of a DNS name (RFC 1123).
+var dnsLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// maxDNSNameLength is the maximum length of a DNS name in its text form, without the trailing dot (RFC 1035).
+const maxDNSNameLength = 253
+
+// dnsZoneValidator validates the DNS zone of a SDN zone.
+func dnsZoneValidator() validator.String {
+	return validators.NewParseValidator(
+		func(s string) (string, error) { return s, parseDNSZone(s) },
+		"must be a DNS name made of dot-separated labels of letters, digits and hyphens, "+
+			"without a trailing dot, e.g. `example.com`",
+	)
+}
+
+// parseDNSZone validates a DNS zone name the way Proxmox does for its `dns-name` format, also enforcing
+// the label and name lengths that the DNS servers would reject.
+func parseDNSZone(zone string) error {
+	if zone == "" {
+		return errors.New("DNS zone must not be empty")
+	}
+
+	if strings.HasSuffix(zone, ".") {
+		return fmt.Errorf("DNS zone %q must not end with a dot, Proxmox doesn't accept the root label", zone)
+	}
+
+	if len(zone) > maxDNSNameLength {
+		return fmt.Errorf("DNS zone %q is longer than %d characters", zone, maxDNSNameLength)
+	}
+
+	for _, label := range strings.Split(zone, ".") {
+		if !dnsLabelRegex.MatchString(label) {
+			return fmt.Errorf("DNS zone %q has an invalid label %q, labels must have 1 to 63 letters, digits "+
+				"or hyphens, and must not start or end with a hyphen", zone, label)
+		}
+	}
+
+	return nil
+}
+
+// listEntryValidator rejects empty list entries and entries containing commas or whitespace. The lists are sent
+// to Proxmox as comma-separated strings, so such entries would be split or merged.
+func listEntryValidator() validator.List {
+	return listvalidator.ValueStringsAre(
+		stringvalidator.RegexMatches(
+			regexp.MustCompile(`^[^,\s]+$`),
+			"must not be empty or contain commas or whitespace, as the list is sent to Proxmox as a comma-separated string",
+		),
+	)
+}
+
+// allNodesValidator rejects wildcards in the nodes of a zone. Proxmox has no all-nodes sentinel, a zone without
+// nodes spans all nodes of the cluster, so a wildcard would be ambiguous with an explicit list of nodes.
+func allNodesValidator() validator.List {
+	return listvalidator.ValueStringsAre(
+		stringvalidator.RegexMatches(
+			regexp.MustCompile(`^[^*]*$`),
+			"must not be a wildcard, a SDN zone without `nodes` spans all nodes of the cluster, "+
+				"so omit `nodes` instead of listing all nodes with `*`",
+		),
+	)
+}
+
+// vxlanPeersRationale explains why a VXLAN zone needs at least one peer.
+const vxlanPeersRationale = "A VXLAN zone needs at least one peer, as the encapsulated traffic is sent " +
+	"to the peers over unicast, so a zone without peers can't reach any other node."
+
+// vxlanPeersValidator requires at least one VXLAN peer, explaining why an empty list is rejected.
+func vxlanPeersValidator() validator.List {
+	return &minPeersValidator{List: listvalidator.SizeAtLeast(1)}
+}
+
+type minPeersValidator struct {
+	validator.List
+}
+
+func (v *minPeersValidator) Description(ctx context.Context) string {
+	return v.List.Description(ctx) + ". " + vxlanPeersRationale
+}
+
+func (v *minPeersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *minPeersValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	inner := &validator.ListResponse{}
+	v.List.ValidateList(ctx, req, inner)
+
+	for _, d := range inner.Diagnostics.Errors() {
+		resp.Diagnostics.AddAttributeError(req.Path, d.Summary(), d.Detail()+" "+vxlanPeersRationale)
+	}
+}
+
+// zoneTypeHint explains how the type of a zone is selected.
+const zoneTypeHint = "The type of a SDN zone is selected by setting exactly one of the `simple`, `vlan`, " +
+	"`vxlan`, `qinq` or `evpn` blocks, e.g. `simple = {}` for a simple zone."
+
+// zoneTypeValidator requires exactly one zone type block, explaining how the type is selected when it fails.
+func zoneTypeValidator() validator.Object {
+	return &exactlyOneZoneTypeValidator{
+		Object: objectvalidator.ExactlyOneOf(
+			path.MatchRoot("simple"),
+			path.MatchRoot("vlan"),
+			path.MatchRoot("vxlan"),
+			path.MatchRoot("qinq"),
+			path.MatchRoot("evpn"),
+		),
+	}
+}
+
+type exactlyOneZoneTypeValidator struct {
+	validator.Object
+}
+
+func (v *exactlyOneZoneTypeValidator) Description(ctx context.Context) string {
+	return v.Object.Description(ctx) + ". " + zoneTypeHint
+}
+
+func (v *exactlyOneZoneTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *exactlyOneZoneTypeValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	inner := &validator.ObjectResponse{}
+	v.Object.ValidateObject(ctx, req, inner)
+
+	for _, d := range inner.Diagnostics.Errors() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid SDN Zone Type", d.Detail()+". "+zoneTypeHint)
+	}
+}
+
+// autoMTUValidator only allows `auto_mtu` on the VXLAN and EVPN zones, the only zones with a VXLAN encapsulation.
+func autoMTUValidator() validator.Bool {
+	return &mtuZoneTypeValidator{
+		zoneTypes:   []string{"vxlan", "evpn"},
+		description: "can only be enabled for VXLAN and EVPN zones",
+		detail:      "The MTU can only be computed automatically for VXLAN and EVPN zones, set `mtu` instead.",
+	}
+}
+
+// bridgeMTUValidator only allows `inherit_bridge_mtu` on the VLAN and QinQ zones, the only zones with a bridge.
+func bridgeMTUValidator() validator.Bool {
+	return &mtuZoneTypeValidator{
+		zoneTypes:   []string{"vlan", "qinq"},
+		description: "can only be enabled for VLAN and QinQ zones",
+		detail:      "The MTU can only be inherited from the bridge of VLAN and QinQ zones, set `mtu` instead.",
+	}
+}
+
+// mtuZoneTypeValidator only allows a way of setting the MTU on the zones of the given types.
+type mtuZoneTypeValidator struct {
+	zoneTypes   []string
+	description string
+	detail      string
+}
+
+func (v *mtuZoneTypeValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+func (v *mtuZoneTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *mtuZoneTypeValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	if !req.ConfigValue.ValueBool() {
+		return
+	}
+
+	for _, zoneType := range v.zoneTypes {
+		var block types.Object
+
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(zoneType), &block)...)
+
+		if resp.Diagnostics.HasError() || !block.IsNull() {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid SDN Zone MTU", v.detail)
+}
+
+// exitNodesValidator warns about the EVPN exit nodes that aren't among the nodes of the zone,
+// as such nodes don't participate in the zone. Zones without nodes span the whole cluster.
+func exitNodesValidator() validator.List {
+	return &exitNodesInZoneValidator{}
+}
+
+type exitNodesInZoneValidator struct{}
+
+func (v *exitNodesInZoneValidator) Description(_ context.Context) string {
+	return "exit nodes should be among the nodes of the zone"
+}
+
+func (v *exitNodesInZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v *exitNodesInZoneValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var zoneNodes types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("nodes"), &zoneNodes)...)
+
+	if resp.Diagnostics.HasError() || zoneNodes.IsUnknown() || spansAllNodes(zoneNodes) {
+		return
+	}
+
+	members := make([]string, 0, len(zoneNodes.Elements()))
+
+	for _, node := range zoneNodes.Elements() {
+		s, ok := node.(types.String)
+		if !ok || s.IsUnknown() {
+			return
+		}
+
+		members = append(members, s.ValueString())
+	}
+
+	for i, node := range req.ConfigValue.Elements() {
+		s, ok := node.(types.String)
+		if !ok || s.IsNull() || s.IsUnknown() || slices.Contains(members, s.ValueString()) {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path.AtListIndex(i),
+			"SDN Zone Exit Node Outside of Zone",
+			fmt.Sprintf("Exit node %s is not among the nodes of the zone (%s), so it doesn't participate in the zone.",
+				s.ValueString(), strings.Join(members, ", ")),
+		)
+	}
+}
@@ -0,0 +1,314 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRouteTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"2-byte ASN", "65000:4294967295", true},
+		{"4-byte ASN", "4200000000:65535", true},
+		{"4-byte ASN with large number", "4200000000:65536", false},
+		{"IPv4", "192.0.2.1:100", true},
+		{"IPv4 with large number", "192.0.2.1:65536", false},
+		{"IPv6", "[2001:db8::1]:100", true},
+		{"IPv6 without brackets", "2001:db8::1:100", false},
+		{"IPv4 in brackets", "[192.0.2.1]:100", false},
+		{"invalid IPv4", "192.0.2.256:100", false},
+		{"missing number", "65000:", false},
+		{"missing administrator", ":100", false},
+		{"no separator", "65000", false},
+		{"non-numeric number", "65000:abc", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := parseRouteTarget(tt.value)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDNSZone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"single label", "lan", true},
+		{"multiple labels", "internal.example.com", true},
+		{"hyphens and digits", "dc-1.example-2.com", true},
+		{"trailing dot", "example.com.", false},
+		{"empty label", "example..com", false},
+		{"leading hyphen", "-example.com", false},
+		{"trailing hyphen", "example-.com", false},
+		{"underscore", "my_zone.example.com", false},
+		{"label too long", strings.Repeat("a", 64) + ".com", false},
+		{"name too long", strings.Repeat("a.", 127) + "com", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := parseDNSZone(tt.value)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVxlanPeersValidator(t *testing.T) {
+	t.Parallel()
+
+	validate := func(value types.List) *validator.ListResponse {
+		resp := &validator.ListResponse{}
+		vxlanPeersValidator().ValidateList(t.Context(), validator.ListRequest{
+			Path:        path.Root("vxlan").AtName("peers"),
+			ConfigValue: value,
+		}, resp)
+
+		return resp
+	}
+
+	resp := validate(types.ListValueMust(types.StringType, []attr.Value{}))
+	require.True(t, resp.Diagnostics.HasError())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), vxlanPeersRationale)
+
+	resp = validate(types.ListValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1")}))
+	require.False(t, resp.Diagnostics.HasError())
+
+	resp = validate(types.ListUnknown(types.StringType))
+	require.False(t, resp.Diagnostics.HasError())
+}
+
+func TestVxlanEmptyPeersSingleError(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	vxlan, ok := schemaResp.Schema.Attributes["vxlan"].(schema.SingleNestedAttribute)
+	require.True(t, ok)
+
+	peers, ok := vxlan.Attributes["peers"].(schema.ListAttribute)
+	require.True(t, ok)
+
+	// All validators of `peers` run on an empty list, it must be reported once, with the rationale.
+	resp := &validator.ListResponse{}
+	for _, v := range peers.Validators {
+		v.ValidateList(ctx, validator.ListRequest{
+			Path:        path.Root("vxlan").AtName("peers"),
+			ConfigValue: types.ListValueMust(types.StringType, []attr.Value{}),
+		}, resp)
+	}
+
+	require.Equal(t, 1, resp.Diagnostics.ErrorsCount(), "%v", resp.Diagnostics)
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), vxlanPeersRationale)
+}
+
+func TestExitNodesValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	stringList := func(values ...string) types.List {
+		list, d := types.ListValueFrom(ctx, types.StringType, values)
+		require.False(t, d.HasError())
+
+		return list
+	}
+
+	tests := []struct {
+		name      string
+		nodes     types.List
+		exitNodes types.List
+		warnings  int
+	}{
+		{"exit nodes in zone", stringList("pve1", "pve2"), stringList("pve1"), 0},
+		{"zone spanning the cluster", types.ListNull(types.StringType), stringList("pve3"), 0},
+		{"exit nodes outside of zone", stringList("pve1"), stringList("pve1", "pve2", "pve3"), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, &sdnZoneResourceModel{
+				Name:  types.StringValue("zone1"),
+				Nodes: tt.nodes,
+				VNets: types.ListNull(types.StringType),
+				EVPN:  &sdnZoneEvpnModel{Exitnodes: tt.exitNodes},
+			}).HasError())
+
+			resp := &validator.ListResponse{}
+			exitNodesValidator().ValidateList(ctx, validator.ListRequest{
+				Path:        path.Root("evpn").AtName("exitnodes"),
+				ConfigValue: tt.exitNodes,
+				Config:      tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+			}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.Equal(t, tt.warnings, resp.Diagnostics.WarningsCount())
+		})
+	}
+}
+
+func TestNodeListValidators(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &sdnZoneResourceModel{
+		Name:  types.StringValue("zone1"),
+		Nodes: types.ListNull(types.StringType),
+		VNets: types.ListNull(types.StringType),
+	}).HasError())
+
+	tests := []struct {
+		name  string
+		path  path.Path
+		nodes []string
+		err   bool
+	}{
+		{"unique nodes", path.Root("nodes"), []string{"pve1", "pve2"}, false},
+		{"no nodes", path.Root("nodes"), []string{}, false},
+		{"duplicate nodes", path.Root("nodes"), []string{"pve1", "pve2", "pve1"}, true},
+		{"duplicate peers", path.Root("vxlan").AtName("peers"), []string{"192.0.2.1", "192.0.2.1"}, true},
+		{"duplicate exit nodes", path.Root("evpn").AtName("exitnodes"), []string{"pve1", "pve1"}, true},
+		{"node with comma", path.Root("nodes"), []string{"pve1,pve2"}, true},
+		{"node with whitespace", path.Root("nodes"), []string{"pve1 "}, true},
+		{"empty node", path.Root("nodes"), []string{""}, true},
+		{"all nodes wildcard", path.Root("nodes"), []string{"*"}, true},
+		{"wildcard with nodes", path.Root("nodes"), []string{"pve*", "pve1"}, true},
+		{"peer with comma", path.Root("vxlan").AtName("peers"), []string{"192.0.2.1,192.0.2.2"}, true},
+		{"exit node with whitespace", path.Root("evpn").AtName("exitnodes"), []string{"pve\t1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attribute, d := schemaResp.Schema.AttributeAtPath(ctx, tt.path)
+			require.False(t, d.HasError())
+
+			list, d := types.ListValueFrom(ctx, types.StringType, tt.nodes)
+			require.False(t, d.HasError())
+
+			resp := &validator.ListResponse{}
+			for _, v := range attribute.(interface{ ListValidators() []validator.List }).ListValidators() {
+				v.ValidateList(ctx, validator.ListRequest{
+					Path:        tt.path,
+					ConfigValue: list,
+					Config:      tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+				}, resp)
+			}
+
+			require.Equal(t, tt.err, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.err {
+				require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tt.nodes[0])
+			}
+		})
+	}
+}
+
+func TestZoneTypeValidator(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&sdnZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tests := []struct {
+		name   string
+		model  sdnZoneResourceModel
+		errors int
+	}{
+		{"one type", sdnZoneResourceModel{Simple: &sdnZoneSimpleModel{}}, 0},
+		{"no type", sdnZoneResourceModel{}, 1},
+		{"two types", sdnZoneResourceModel{
+			Simple: &sdnZoneSimpleModel{},
+			VLAN:   &sdnZoneVlanModel{Bridge: types.StringValue("vmbr0")},
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.model.Name = types.StringValue("zone1")
+			tt.model.Nodes = types.ListNull(types.StringType)
+			tt.model.VNets = types.ListNull(types.StringType)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(ctx, &tt.model).HasError())
+
+			config := tfsdk.Config{Schema: state.Schema, Raw: state.Raw}
+
+			var simple types.Object
+			require.False(t, config.GetAttribute(ctx, path.Root("simple"), &simple).HasError())
+
+			resp := &validator.ObjectResponse{}
+			zoneTypeValidator().ValidateObject(ctx, validator.ObjectRequest{
+				Path:           path.Root("simple"),
+				PathExpression: path.MatchRoot("simple"),
+				ConfigValue:    simple,
+				Config:         config,
+			}, resp)
+			require.Equal(t, tt.errors, resp.Diagnostics.ErrorsCount(), "%v", resp.Diagnostics)
+
+			for _, d := range resp.Diagnostics.Errors() {
+				require.Contains(t, d.Detail(), zoneTypeHint)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// verifyTimeout is how long a zone created with `verify_on_create` may take to become available on its nodes.
+const verifyTimeout = 2 * time.Minute
+
+// verify applies the SDN configuration and waits until the created zone is available on all its nodes,
+// failing with an error otherwise. Zones without nodes are verified on all online nodes of the cluster.
+func (r *sdnZoneResource) verify(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	name := model.Name.ValueString()
+
+	err := r.client.Cluster().SDN().Apply(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Applying SDN Configuration",
+			fmt.Sprintf("SDN zone %s was created, but applying the SDN configuration to verify it failed: %s", name, err),
+		)
+		return
+	}
+
+	nodeNames, err := r.zoneNodeNames(ctx, model.Nodes, diags)
+	if err != nil {
+		diags.AddError(
+			"Error Verifying SDN Zone",
+			fmt.Sprintf("SDN zone %s was created and applied, but the cluster nodes could not be listed: %s", name, err),
+		)
+		return
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	err = r.client.Cluster().SDN().VerifyZone(ctx, name, nodeNames, verifyTimeout)
+	if err != nil {
+		diags.AddError(
+			"SDN Zone Verification Failed",
+			fmt.Sprintf("SDN zone %s was created and applied, but it is not active on its nodes: %s. "+
+				"Check the SDN status of the nodes, the zone is replaced by the next apply.", name, err),
+		)
+	}
+}
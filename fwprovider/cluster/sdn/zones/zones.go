@@ -8,29 +8,50 @@ package sdn_zones
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
-	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	proxmoxsdn "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+const controllerWaitAttempts = 10
+
+// controllerWaitDelay is the delay between the attempts to create an EVPN zone whose controller doesn't exist yet.
+// It is a variable so tests can shorten it.
+var controllerWaitDelay = 3 * time.Second
+
 var (
-	_ resource.Resource              = &sdnZoneResource{}
-	_ resource.ResourceWithConfigure = &sdnZoneResource{}
+	_ resource.Resource                = &sdnZoneResource{}
+	_ resource.ResourceWithConfigure   = &sdnZoneResource{}
+	_ resource.ResourceWithModifyPlan  = &sdnZoneResource{}
+	_ resource.ResourceWithImportState = &sdnZoneResource{}
 )
 
 // NewSdnZoneResource creates a new instance of the sdn zone resource.
@@ -40,7 +61,12 @@ func NewSdnZoneResource() resource.Resource {
 }
 
 type sdnZoneResource struct {
-	client proxmox.Client
+	client        proxmox.Client
+	strict        bool
+	quietNotFound bool
+	apiValidation bool
+	defaultIPAM   string
+	capabilities  *proxmoxsdn.CapabilitiesCache
 }
 
 // Metadata returns the resource type name.
@@ -68,58 +94,175 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required:    true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(3),
+					reservedZoneNameValidator(),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"type": schema.StringAttribute{
+				Description: "Type of the SDN zone, derived from the configured zone block, " +
+					"e.g. `simple` or `vxlan`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"mtu": schema.Int32Attribute{
-				Description: "MTU",
-				Optional:    true,
+				Description: "MTU of the SDN zone. The MTU is applied to the zone on all its nodes, " +
+					"Proxmox doesn't support per-node MTU values for SDN zones. In heterogeneous clusters, " +
+					"use the lowest MTU supported by all nodes, or split the nodes into separate zones. " +
+					"Set to `0` to explicitly inherit the MTU of the system, the same as when the attribute is omitted. " +
+					"VXLAN and EVPN zones can compute it with `auto_mtu` instead, VLAN and QinQ zones can inherit " +
+					"it from their bridge with `inherit_bridge_mtu`, and `jumbo` sets it to 9000.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.Any(
+						int32validator.OneOf(inheritZoneMTU),
+						int32validator.Between(minZoneMTU, maxZoneMTU),
+					),
+				},
+			},
+			"auto_mtu": schema.BoolAttribute{
+				Description: "Whether to compute the MTU of a VXLAN or EVPN zone from the MTU of the interface " +
+					"of the default route of its nodes, minus the 50 bytes of the VXLAN encapsulation. The lowest " +
+					"MTU of the nodes of the zone, or of all online nodes of the cluster for a zone without nodes, " +
+					"is used. The MTU is computed on every change of the zone, the result is reported in " +
+					"`computed_mtu`. Conflicts with `mtu`. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("mtu")),
+					autoMTUValidator(),
+				},
+			},
+			"inherit_bridge_mtu": schema.BoolAttribute{
+				Description: "Whether to set the MTU of a VLAN or QinQ zone to the MTU of its bridge. The lowest " +
+					"MTU of the bridge on the nodes of the zone, or on all online nodes of the cluster for a zone " +
+					"without nodes, is used. The MTU is read on every change of the zone, the result is reported " +
+					"in `computed_mtu`. Conflicts with `mtu` and `auto_mtu`. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("mtu"), path.MatchRoot("auto_mtu")),
+					bridgeMTUValidator(),
+				},
+			},
+			"jumbo": schema.BoolAttribute{
+				Description: "Whether the zone uses jumbo frames, setting its MTU to 9000. A zone whose MTU " +
+					"was changed outside of Terraform is read back with `jumbo` disabled, so that the next apply " +
+					"restores it. Conflicts with `mtu`, `auto_mtu` and `inherit_bridge_mtu`. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("mtu"), path.MatchRoot("auto_mtu"),
+						path.MatchRoot("inherit_bridge_mtu")),
+				},
+			},
+			"computed_mtu": schema.Int32Attribute{
+				Description: "MTU of the zone computed by `auto_mtu` or inherited from the bridge by `inherit_bridge_mtu`.",
+				Computed:    true,
 			},
 			"nodes": schema.ListAttribute{
-				Description: "List of nodes that are part of the SDN zone.",
+				Description: "List of nodes that are part of the SDN zone. A zone without nodes, or with an " +
+					"empty list of nodes, spans all nodes of the cluster. To leave out the offline nodes, set it " +
+					"from the online nodes of the `proxmox_virtual_environment_nodes` data source, e.g. " +
+					"`[for i, name in data.proxmox_virtual_environment_nodes.all.names : name if " +
+					"data.proxmox_virtual_environment_nodes.all.online[i]]`.",
 				Optional:    true,
 				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listEntryValidator(),
+					allNodesValidator(),
+				},
 			},
 			"ipam": schema.StringAttribute{
-				Description: "IPAM name",
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString("pve"),
+				Description: "Name of the IPAM of the zone. Defaults to the `sdn_default_ipam` of the " +
+					"provider, or the built-in `pve` IPAM, set to an empty string for a zone without IPAM. " +
+					"Zones without IPAM, e.g. created outside of Terraform, are read back with an empty string.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(ipams.DefaultIPAM),
+			},
+			"ipam_change_requires_replace": schema.BoolAttribute{
+				Description: "Whether a change of `ipam` replaces the zone instead of updating it in place. " +
+					"The IPAM of a zone can't be changed cleanly while its subnets have addresses registered " +
+					"in it. The replacement deletes the zone and creates it again, which Proxmox refuses " +
+					"while VNets use the zone, and the provider doesn't manage VNets, so they aren't recreated: " +
+					"the VNets of the zone, along with their subnets and registered addresses, must be removed " +
+					"beforehand, otherwise the replacement fails. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"dns": schema.StringAttribute{
 				Description: "DNS api server",
 				Optional:    true,
 			},
 			"reversedns": schema.StringAttribute{
-				Description: "Reverse DNS api server",
-				Optional:    true,
+				Description: "Reverse DNS api server. This is the name of a SDN DNS server, not a reverse " +
+					"DNS zone, the reverse zones are derived from the subnets of the zone.",
+				Optional: true,
 			},
 			"dnszone": schema.StringAttribute{
-				Description: "DNS zone name",
-				Optional:    true,
+				Description: "DNS zone name. Hostnames are registered in this DNS zone, " +
+					"so it can only be set together with the `dns` server.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("dns")),
+					dnsZoneValidator(),
+				},
+			},
+			"list_vnets": schema.BoolAttribute{
+				Description: "Whether to list the VNets of the zone in `vnets`. " +
+					"Requires an extra API call on every refresh. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"vnets": schema.ListAttribute{
+				Description: "Names of the VNets belonging to the zone, only set when `list_vnets` is enabled.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Whether Terraform only tracks the zone without modifying it, e.g. for a zone " +
+					"imported from a configuration managed elsewhere. Changes of the zone are not applied " +
+					"and the zone is removed from the state without being deleted, both with a warning. " +
+					"The state is still refreshed. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"verify_on_create": schema.BoolAttribute{
+				Description: "Whether to apply the SDN configuration after creating the zone and verify that " +
+					"the zone is available on all its nodes, failing the creation otherwise. Applying the " +
+					"configuration also applies all other pending SDN changes of the cluster. A zone failing " +
+					"the verification is kept, tainted, and replaced by the next apply. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
 			"simple": schema.SingleNestedAttribute{
 				Description: "Simple SDN zone configuration.",
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"dhcp": schema.StringAttribute{
-						Description: "Enable automatic DHCP.",
-						Optional:    true,
+						Description: "Enable automatic DHCP. Addresses are only leased from the DHCP ranges " +
+							"(`dhcp_range`) of the subnets of the zone, when `sdn_api_validation` is enabled in the provider, " +
+							"a warning is reported if none of them has a range.",
+						Optional: true,
 						Validators: []validator.String{
 							stringvalidator.OneOf("dnsmasq"),
 						},
 					},
 				},
 				Validators: []validator.Object{
-					objectvalidator.ExactlyOneOf(
-						path.MatchRoot("simple"),
-						path.MatchRoot("vlan"),
-						path.MatchRoot("vxlan"),
-						path.MatchRoot("qinq"),
-						path.MatchRoot("evpn"),
-					),
+					zoneTypeValidator(),
 				},
 				PlanModifiers: []planmodifier.Object{
 					recreatemodifier,
@@ -130,8 +273,10 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"bridge": schema.StringAttribute{
-						Description: "Bridge to use for the VLAN zone.",
-						Required:    true,
+						Description: "Bridge to use for the VLAN zone. A bridge that isn't VLAN-aware is used " +
+							"through VLAN sub-interfaces. When `sdn_api_validation` is enabled in the provider, " +
+							"a warning is reported if the bridge is missing on the nodes of the zone.",
+						Required: true,
 					},
 				},
 				PlanModifiers: []planmodifier.Object{
@@ -146,11 +291,17 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Description: "List of peer nodes for the VXLAN zone.",
 						Required:    true,
 						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.UniqueValues(),
+							listEntryValidator(),
+							vxlanPeersValidator(),
+						},
 					},
 					"port": schema.Int32Attribute{
-						Description: "Vxlan tunnel udp port.",
+						Description: fmt.Sprintf("Vxlan tunnel udp port. Defaults to `%d`.", defaultVxlanPort),
 						Optional:    true,
 						Computed:    true,
+						Default:     int32default.StaticInt32(defaultVxlanPort),
 					},
 				},
 				PlanModifiers: []planmodifier.Object{
@@ -166,16 +317,21 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Required:    true,
 					},
 					"tag": schema.Int32Attribute{
-						Description: "VLAN tag for the QinQ zone.",
+						Description: "Service VLAN tag (outer tag) for the QinQ zone.",
 						Required:    true,
+						Validators: []validator.Int32{
+							int32validator.Between(minVlanTag, maxVlanTag),
+						},
 					},
 					"vlan_protocol": schema.StringAttribute{
-						Description: "VLAN protocol for the QinQ zone.",
-						Optional:    true,
-						Computed:    true,
-						Default:     stringdefault.StaticString("802.1q"),
+						Description: "Protocol of the service VLAN tag for the QinQ zone: `802.1q` tags the outer " +
+							"VLAN with the 0x8100 ethertype, `802.1ad` with the 0x88a8 ethertype. Proxmox doesn't " +
+							"support custom ethertypes. Defaults to `802.1q`.",
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(vlanProtocol8021Q),
 						Validators: []validator.String{
-							stringvalidator.OneOf("802.1q", "802.1ad"),
+							stringvalidator.OneOf(vlanProtocol8021Q, vlanProtocol8021AD),
 						},
 					},
 				},
@@ -188,12 +344,37 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"controller": schema.StringAttribute{
-						Description: "EVPN controller address.",
-						Required:    true,
+						Description: "Name of the EVPN controller of the zone. Prefer referencing the `name` of " +
+							"the controller resource, so that Terraform creates the controller first. When the " +
+							"controller is set by a plain name and is created in the same apply, the creation " +
+							"of the zone waits for it.",
+						Required: true,
+					},
+					"vrf_vxlan": schema.Int32Attribute{
+						Description: fmt.Sprintf("VRF VXLAN ID for the EVPN zone, between `%d` and `%d`. "+
+							"Required, unless the deprecated `vfr_vxlan` is set instead.", minVxlanID, maxVxlanID),
+						Optional: true,
+						Validators: []validator.Int32{
+							int32validator.Between(minVxlanID, maxVxlanID),
+							int32validator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("vfr_vxlan")),
+						},
 					},
 					"vfr_vxlan": schema.Int32Attribute{
-						Description: "VRF VXLAN ID for the EVPN zone.",
-						Required:    true,
+						Description: "Deprecated misspelled name of `vrf_vxlan`.",
+						DeprecationMessage: "The `vfr_vxlan` attribute is deprecated and will be removed in a future release. " +
+							"Use `vrf_vxlan` instead.",
+						Optional: true,
+						Validators: []validator.Int32{
+							int32validator.Between(minVxlanID, maxVxlanID),
+						},
+					},
+					"vrf": schema.StringAttribute{
+						Description: "Name of the VRF of the EVPN zone. Proxmox derives it from the zone name " +
+							"and doesn't support setting it explicitly.",
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
 					"mac": schema.StringAttribute{
 						Description: "Anycast logical router mac address.",
@@ -201,10 +382,28 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Computed:    true,
 					},
 					"exitnodes": schema.ListAttribute{
-						Description: "List of exit nodes for the EVPN zone.",
+						Description: "List of exit nodes for the EVPN zone. The exit nodes should be " +
+							"among the `nodes` of the zone.",
 						Optional:    true,
 						Computed:    true,
 						ElementType: types.StringType,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.UniqueValues(),
+							listEntryValidator(),
+							exitNodesValidator(),
+						},
+					},
+					"exitnodes_auto": schema.BoolAttribute{
+						Description: "Whether to use all nodes of the zone as exit nodes, or all online nodes " +
+							"of the cluster for a zone without nodes. The exit nodes are selected on every change " +
+							"of the zone and reported in `exitnodes`. Conflicts with `exitnodes`. Defaults to `false`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+						Validators: []validator.Bool{
+							boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("exitnodes")),
+						},
 					},
 					"exitnodes_primary": schema.StringAttribute{
 						Description: "Primary exit node for the EVPN zone.",
@@ -227,8 +426,23 @@ func (r *sdnZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 						Computed:    true,
 					},
 					"rt_import": schema.StringAttribute{
-						Description: "Route target import.",
-						Optional:    true,
+						Description: "Comma-separated list of route targets to import, " +
+							"e.g. `65000:100,192.0.2.1:100,[2001:db8::1]:100`.",
+						Optional: true,
+						Computed: true,
+						Validators: []validator.String{
+							routeTargetListValidator(),
+						},
+					},
+					"resolve_controller_asn": schema.BoolAttribute{
+						Description: "Whether to resolve the ASN of the EVPN controller into `controller_asn`. " +
+							"Requires an extra API call on every refresh. Defaults to `false`.",
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+					"controller_asn": schema.Int64Attribute{
+						Description: "ASN of the EVPN controller, only set when `resolve_controller_asn` is enabled.",
 						Computed:    true,
 					},
 				},
@@ -255,6 +469,131 @@ func (r *sdnZoneResource) Configure(_ context.Context, req resource.ConfigureReq
 	}
 
 	r.client = cfg.Client
+	r.strict = cfg.SDNStrict
+	r.quietNotFound = cfg.SDNQuietNotFound
+	r.apiValidation = cfg.SDNAPIValidation
+	r.defaultIPAM = cfg.SDNDefaultIPAM
+	r.capabilities = cfg.SDNCapabilities
+}
+
+// ModifyPlan validates the planned zone against the existing cluster configuration,
+// when enabled by the `sdn_api_validation` provider option.
+func (r *sdnZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.applyDefaultIPAM(ctx, req, resp)
+	r.planIPAMReplacement(ctx, req, resp)
+	r.planComputedMTU(ctx, resp)
+
+	if r.keepReadOnlyZone(ctx, req, resp) || !r.apiValidation || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan sdnZoneResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkReferences(ctx, &plan, &resp.Diagnostics)
+
+	if plan.EVPN != nil {
+		r.checkEvpnCollisions(ctx, plan.Name.ValueString(), plan.EVPN, req.State.Raw.IsNull(), &resp.Diagnostics)
+	}
+
+	var bridge types.String
+
+	switch {
+	case plan.VLAN != nil:
+		bridge = plan.VLAN.Bridge
+	case plan.QinQ != nil:
+		bridge = plan.QinQ.Bridge
+	}
+
+	if bridge.IsNull() || bridge.IsUnknown() || plan.Nodes.IsUnknown() {
+		return
+	}
+
+	for _, node := range plan.Nodes.Elements() {
+		if node.IsUnknown() {
+			return
+		}
+	}
+
+	r.checkBridge(ctx, bridge.ValueString(), plan.Nodes, &resp.Diagnostics)
+}
+
+// applyDefaultIPAM replaces the built-in default IPAM of the plan by the default IPAM of the provider,
+// unless the zone configuration sets the IPAM explicitly. Schema defaults are static and can't depend
+// on the provider configuration, so the default is applied after them.
+func (r *sdnZoneResource) applyDefaultIPAM(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.defaultIPAM == "" {
+		return
+	}
+
+	var ipam types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ipam"), &ipam)...)
+	if resp.Diagnostics.HasError() || !ipam.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ipam"), types.StringValue(r.defaultIPAM))...)
+}
+
+// planIPAMReplacement requires the replacement of the zone on a change of its IPAM, when enabled by
+// `ipam_change_requires_replace`. It runs after applyDefaultIPAM, so that a change of the default IPAM
+// of the provider replaces the zone too.
+func (r *sdnZoneResource) planIPAMReplacement(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var requiresReplace types.Bool
+
+	var planned, current types.String
+
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("ipam_change_requires_replace"), &requiresReplace)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("ipam"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ipam"), &current)...)
+
+	if resp.Diagnostics.HasError() || !requiresReplace.ValueBool() || planned.IsUnknown() || planned.Equal(current) {
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("ipam"))
+}
+
+// keepReadOnlyZone replaces the planned changes of a read-only zone by its current state, except for
+// the read-only flag itself. It reports whether the plan was replaced.
+func (r *sdnZoneResource) keepReadOnlyZone(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return false
+	}
+
+	var plan, state sdnZoneResourceModel
+
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || !plan.ReadOnly.ValueBool() {
+		return false
+	}
+
+	state.ReadOnly = plan.ReadOnly
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &state)...)
+	resp.RequiresReplace = nil
+
+	if !resp.Plan.Raw.Equal(req.Plan.Raw) {
+		sdn.AddWarning(&resp.Diagnostics, r.strict,
+			"Read-Only SDN Zone Not Modified",
+			fmt.Sprintf("SDN zone %s is read-only, its configuration changes are ignored", state.Name.ValueString()),
+		)
+	}
+
+	return true
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -266,44 +605,199 @@ func (r *sdnZoneResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	err := r.client.Cluster().SDN().Zones().Create(ctx, plan.exportToSdnZoneBody(ctx, &resp.Diagnostics))
+	r.resolveAutoMTU(ctx, &plan, &resp.Diagnostics)
+	r.resolveBridgeMTU(ctx, &plan, &resp.Diagnostics)
+	r.resolveAutoExitnodes(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.create(ctx, &plan, &resp.Diagnostics)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if sdn.AddNotAvailableError(&resp.Diagnostics, err) || r.addUnsupportedTypeError(ctx, &plan, &resp.Diagnostics) {
+			return
+		}
+
+		sdn.AddAPIError(&resp.Diagnostics,
 			"Error Creating SDN Zone",
-			fmt.Sprintf("Failed to list SDN zones: %s", err),
+			fmt.Sprintf("Failed to create %s SDN zone %s", plan.zoneType(), plan.Name.ValueString()),
+			err, plan.attributePath,
 		)
 		return
 	}
 
-	r.read(ctx, &plan, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
+	// The zone exists at this point, so a failed read must not drop it from the state,
+	// otherwise it would be orphaned and the next apply would try to create it again.
+	created := plan
+	readDiags := diag.Diagnostics{}
+
+	r.read(ctx, &created, &readDiags)
+
+	if readDiags.HasError() {
+		for _, d := range readDiags {
+			resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
+		}
+
+		resp.Diagnostics.AddWarning(
+			"SDN Zone Created With Unknown State",
+			fmt.Sprintf("SDN zone %s was created, but its state could not be read back. "+
+				"The planned values are stored instead, the next refresh reconciles them.", plan.Name.ValueString()),
+		)
+
+		plan.resolveUnknowns()
+	} else {
+		resp.Diagnostics.Append(readDiags...)
+		plan = created
+
+		r.checkAdvertiseSubnets(ctx, &plan, &resp.Diagnostics)
+		r.checkDHCPRanges(ctx, &plan, &resp.Diagnostics)
+	}
+
+	// The zone is stored even if the verification fails, so that Terraform taints it instead of orphaning it.
+	if plan.VerifyOnCreate.ValueBool() {
+		r.verify(ctx, &plan, &resp.Diagnostics)
 	}
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
 
+// create creates the zone. An EVPN zone referencing its controller by name, rather than through the controller
+// resource, doesn't depend on it, so Terraform may create both concurrently. Proxmox rejects the zone until the
+// controller exists, so the creation is retried for a while, giving the controller the time to be created.
+func (r *sdnZoneResource) create(ctx context.Context, plan *sdnZoneResourceModel, diags *diag.Diagnostics) error {
+	body := plan.exportToSdnZoneBody(ctx, diags)
+
+	var controller string
+	if plan.EVPN != nil {
+		controller = plan.EVPN.Controller.ValueString()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.client.Cluster().SDN().Zones(zones.WithSerializedWrites()).Create(ctx, body)
+		if err == nil || controller == "" || attempt == controllerWaitAttempts || !errors.Is(err, api.ErrResourceDoesNotExist) {
+			return err
+		}
+
+		tflog.Debug(ctx, "waiting for the SDN controller of the zone to be created", map[string]any{
+			"zone":       plan.Name.ValueString(),
+			"controller": controller,
+			"attempt":    attempt,
+		})
+
+		select {
+		case <-time.After(controllerWaitDelay):
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for SDN controller %s: %w", controller, ctx.Err())
+		}
+	}
+}
+
 // read fetches the current state of the resource from the Proxmox API and updates the model.
-func (r *sdnZoneResource) read(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
-	zone, err := r.client.Cluster().SDN().Zones().Get(ctx, model.Name.ValueString())
+// It returns false if the zone doesn't exist.
+func (r *sdnZoneResource) read(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) bool {
+	// The pending view is read, as depending on the Proxmox version, a zone deleted without applying the SDN
+	// configuration may still be returned from the running configuration. Its staged deletion is reported as
+	// not existing.
+	zone, err := r.client.Cluster().SDN().Zones().GetPending(ctx, model.Name.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
-			diags.AddWarning(
-				"SDN Zone Not Found",
-				fmt.Sprintf("SDN zone %s does not exist, setting to empty state", model.Name.ValueString()),
-			)
-			model.RemoveAllAttributes()
-		} else {
-			diags.AddError(
-				"Error listing SDN Zones",
-				fmt.Sprintf("Failed to list SDN zones: %s", err),
+		if sdn.AddNotAvailableError(diags, err) {
+			return true
+		}
+
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			r.removeMissingZone(model, diags,
+				fmt.Sprintf("SDN zone %s does not exist or is deleted pending SDN apply, removing it from the state",
+					model.Name.ValueString()),
 			)
+
+			return false
 		}
-		return
+
+		diags.AddError(
+			"Error Reading SDN Zone",
+			fmt.Sprintf("Failed to read SDN zone %s: %s", model.Name.ValueString(), err),
+		)
+
+		return true
 	}
 
 	model.importFromSdnZoneBody(ctx, zone, diags)
+
+	if model.EVPN != nil && model.EVPN.ResolveControllerASN.ValueBool() && zone.Controller != nil {
+		r.resolveControllerASN(ctx, *zone.Controller, model.EVPN, diags)
+	}
+
+	model.VNets = types.ListNull(types.StringType)
+
+	if model.ListVNets.ValueBool() {
+		r.listVNets(ctx, model, diags)
+	}
+
+	return true
+}
+
+// removeMissingZone removes a zone that no longer exists from the model.
+func (r *sdnZoneResource) removeMissingZone(model *sdnZoneResourceModel, diags *diag.Diagnostics, detail string) {
+	if !r.quietNotFound {
+		sdn.AddWarning(diags, r.strict, "SDN Zone Not Found", detail)
+	}
+
+	model.RemoveAllAttributes()
+}
+
+// isDeletionPending returns whether the deletion of a zone is staged but not yet applied.
+// Errors are not reported, the zone is then considered not deleted.
+func (r *sdnZoneResource) isDeletionPending(ctx context.Context, name string) bool {
+	state, err := r.client.Cluster().SDN().Zones().PendingState(ctx, name)
+
+	return err == nil && state == zones.PendingStateDeleted
+}
+
+// listVNets sets the names of the zone's VNets in the model, or leaves them empty with a warning
+// if they can't be listed.
+func (r *sdnZoneResource) listVNets(ctx context.Context, model *sdnZoneResourceModel, diags *diag.Diagnostics) {
+	list, err := r.client.Cluster().SDN().VNets().ListByZone(ctx, model.Name.ValueString())
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to List SDN Zone VNets",
+			fmt.Sprintf("Failed to list SDN VNets of zone %s, `vnets` is left empty", model.Name.ValueString()),
+			err,
+		)
+
+		model.VNets = types.ListNull(types.StringType)
+
+		return
+	}
+
+	names := make([]string, 0, len(list))
+	for _, vnet := range list {
+		names = append(names, vnet.Name)
+	}
+
+	var d diag.Diagnostics
+
+	model.VNets, d = types.ListValueFrom(ctx, types.StringType, names)
+	diags.Append(d...)
+}
+
+// resolveControllerASN sets the ASN of the zone's EVPN controller in the model, or leaves it empty with a warning
+// if the controller can't be read.
+func (r *sdnZoneResource) resolveControllerASN(ctx context.Context, controller string, model *sdnZoneEvpnModel, diags *diag.Diagnostics) {
+	ctrl, err := r.client.Cluster().SDN().Controllers().Get(ctx, controller)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Resolve SDN Controller ASN",
+			fmt.Sprintf("Failed to read SDN controller %s to resolve its ASN, `controller_asn` is left empty", controller),
+			err,
+		)
+
+		model.ControllerASN = types.Int64Null()
+
+		return
+	}
+
+	model.ControllerASN = types.Int64PointerValue(ctrl.Asn)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -315,11 +809,16 @@ func (r *sdnZoneResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	r.read(ctx, &state, &resp.Diagnostics)
+	found := r.read(ctx, &state, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -333,11 +832,29 @@ func (r *sdnZoneResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	err := r.client.Cluster().SDN().Zones().Update(ctx, plan.Name.ValueString(), plan.exportToUpdateBody(ctx, &resp.Diagnostics))
+	// The changes of a read-only zone were dropped from the plan, only the read-only flag is updated.
+	if plan.ReadOnly.ValueBool() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	r.resolveAutoMTU(ctx, &plan, &resp.Diagnostics)
+	r.resolveBridgeMTU(ctx, &plan, &resp.Diagnostics)
+	r.resolveAutoExitnodes(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Cluster().SDN().Zones(zones.WithSerializedWrites()).Update(ctx, plan.Name.ValueString(), plan.exportToUpdateBody(ctx, &resp.Diagnostics))
 	if err != nil {
-		resp.Diagnostics.AddError(
+		if sdn.AddNotAvailableError(&resp.Diagnostics, err) {
+			return
+		}
+
+		sdn.AddAPIError(&resp.Diagnostics,
 			"Error Updating SDN Zone",
-			fmt.Sprintf("Failed to update SDN zone %s: %s", plan.Name.ValueString(), err),
+			fmt.Sprintf("Failed to update SDN zone %s", plan.Name.ValueString()),
+			err, plan.attributePath,
 		)
 		return
 	}
@@ -347,6 +864,9 @@ func (r *sdnZoneResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	r.checkAdvertiseSubnets(ctx, &plan, &resp.Diagnostics)
+	r.checkDHCPRanges(ctx, &plan, &resp.Diagnostics)
+
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -360,14 +880,32 @@ func (r *sdnZoneResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.Cluster().SDN().Zones().Delete(ctx, state.Name.ValueString())
+	if state.ReadOnly.ValueBool() {
+		sdn.AddWarning(&resp.Diagnostics, r.strict,
+			"Read-Only SDN Zone Not Deleted",
+			fmt.Sprintf("SDN zone %s is read-only, removing it from the state without deleting it", state.Name.ValueString()),
+		)
+		return
+	}
+
+	err := r.client.Cluster().SDN().Zones(zones.WithSerializedWrites()).Delete(ctx, state.Name.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "does not exist") {
-			resp.Diagnostics.AddWarning(
-				"SDN Zone Not Found",
-				fmt.Sprintf("SDN zone %s does not exist, skipping deletion", state.Name.ValueString()),
-			)
-		} else {
+		if sdn.AddNotAvailableError(&resp.Diagnostics, err) {
+			return
+		}
+
+		// The zone is deleted by name, so a zone of a type unknown to the provider is deleted the same way.
+		switch {
+		case errors.Is(err, api.ErrResourceDoesNotExist):
+			if !r.quietNotFound {
+				sdn.AddWarning(&resp.Diagnostics, r.strict,
+					"SDN Zone Not Found",
+					fmt.Sprintf("SDN zone %s does not exist, skipping deletion", state.Name.ValueString()),
+				)
+			}
+		case r.isDeletionPending(ctx, state.Name.ValueString()):
+			// The zone was already deleted, e.g. by a previous attempt that timed out, only the SDN apply is pending.
+		default:
 			resp.Diagnostics.AddError(
 				"Error Deleting SDN Zone",
 				fmt.Sprintf("Failed to delete SDN zone %s: %s", state.Name.ValueString(), err),
@@ -376,3 +914,97 @@ func (r *sdnZoneResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 }
+
+// ImportState imports an existing SDN zone by its name. With the `<name>?recursive=true` ID, the import blocks
+// of the subnets of the zone are reported in a warning, as Terraform only imports one resource at a time.
+func (r *sdnZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, rawQuery, _ := strings.Cut(req.ID, "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid SDN Zone Import ID",
+			fmt.Sprintf("Failed to parse the options of the import ID %s, expected `<name>` or `<name>?recursive=true`: %s",
+				req.ID, err),
+		)
+		return
+	}
+
+	model := sdnZoneResourceModel{
+		Name:      types.StringValue(name),
+		Nodes:     types.ListNull(types.StringType),
+		VNets:     types.ListNull(types.StringType),
+		ListVNets: types.BoolValue(false),
+		ReadOnly:  types.BoolValue(false),
+		AutoMTU:   types.BoolValue(false),
+		BridgeMTU: types.BoolValue(false),
+		Jumbo:     types.BoolValue(false),
+
+		VerifyOnCreate: types.BoolValue(false),
+		IPAMReplace:    types.BoolValue(false),
+	}
+
+	zone, err := r.client.Cluster().SDN().Zones().Get(ctx, name)
+	if err != nil {
+		if sdn.AddNotAvailableError(&resp.Diagnostics, err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Importing SDN Zone",
+			fmt.Sprintf("Failed to read SDN zone %s: %s", name, err),
+		)
+		return
+	}
+
+	model.importFromSdnZoneBody(ctx, zone, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+
+	if query.Get("recursive") == "true" {
+		r.reportDependentImports(ctx, name, &resp.Diagnostics)
+	}
+}
+
+// reportDependentImports reports the import blocks of the subnets of the zone in a warning. VNets have no
+// resource in the provider, so they are only listed.
+func (r *sdnZoneResource) reportDependentImports(ctx context.Context, zone string, diags *diag.Diagnostics) {
+	targets, err := r.client.Cluster().SDN().EnumerateZoneForImport(ctx, zone)
+	if err != nil {
+		sdn.AddBestEffortWarning(diags,
+			"Unable to Enumerate SDN Zone Dependents",
+			fmt.Sprintf("SDN zone %s was imported, but its VNets and subnets couldn't be listed", zone),
+			err,
+		)
+		return
+	}
+
+	var (
+		vnetNames []string
+		subnets   []proxmoxsdn.ImportTarget
+	)
+
+	for _, target := range targets {
+		if target.ListOnly {
+			vnetNames = append(vnetNames, target.ImportID)
+		} else {
+			subnets = append(subnets, target)
+		}
+	}
+
+	if len(vnetNames) == 0 {
+		return
+	}
+
+	detail := fmt.Sprintf("SDN zone %s has the VNets %s, which aren't managed by a resource of the provider.",
+		zone, strings.Join(vnetNames, ", "))
+
+	if len(subnets) > 0 {
+		detail += " Add the following blocks to the configuration to import their subnets:\n\n" + proxmoxsdn.ImportBlocks(subnets)
+	}
+
+	diags.AddWarning("SDN Zone Dependents Not Imported", detail)
+}
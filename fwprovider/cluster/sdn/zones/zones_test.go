@@ -0,0 +1,392 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_zones_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+)
+
+func TestAccResourceSdnZoneMTU(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	zoneName := fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999))
+	te.AddTemplateVars(map[string]any{
+		"ZoneName": zoneName,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name   = "{{.ZoneName}}"
+					mtu    = 9000
+					simple = {}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+						"name": zoneName,
+						"type": "simple",
+						"mtu":  "9000",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name   = "{{.ZoneName}}"
+					simple = {}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_zone.test", []string{
+						"mtu",
+					}),
+					func(*terraform.State) error {
+						zone, err := te.ClusterClient().SDN().Zones().Get(context.Background(), zoneName)
+						if err != nil {
+							return fmt.Errorf("failed to read SDN zone %s: %w", zoneName, err)
+						}
+
+						if zone.Mtu != nil {
+							return fmt.Errorf("expected SDN zone %s to have no MTU, got %d", zoneName, *zone.Mtu)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSdnZoneDeletedOutsideTerraform(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	zoneName := fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999))
+	te.AddTemplateVars(map[string]any{
+		"ZoneName": zoneName,
+	})
+
+	config := te.RenderConfig(`
+	resource "proxmox_virtual_environment_sdn_zone" "test" {
+		name   = "{{.ZoneName}}"
+		simple = {}
+	}`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+					"name": zoneName,
+					"type": "simple",
+				}),
+			},
+			{
+				// The refresh of a zone deleted outside of Terraform only warns, and removes it from the state.
+				PreConfig: func() {
+					err := te.ClusterClient().SDN().Zones().Delete(context.Background(), zoneName)
+					if err != nil {
+						t.Fatalf("failed to delete SDN zone %s: %s", zoneName, err)
+					}
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: func(s *terraform.State) error {
+					if _, ok := s.RootModule().Resources["proxmox_virtual_environment_sdn_zone.test"]; ok {
+						return fmt.Errorf("expected SDN zone %s to be removed from the state", zoneName)
+					}
+
+					return nil
+				},
+			},
+			{
+				// The removed zone is created again.
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("proxmox_virtual_environment_sdn_zone.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+					"type": "simple",
+				}),
+			},
+		},
+	})
+}
+
+func TestAccResourceSdnZoneVxlanPortDrift(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	zoneName := fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999))
+	te.AddTemplateVars(map[string]any{
+		"ZoneName": zoneName,
+	})
+
+	config := te.RenderConfig(`
+	resource "proxmox_virtual_environment_sdn_zone" "test" {
+		name  = "{{.ZoneName}}"
+		vxlan = {
+			peers = ["10.0.0.1", "10.0.0.2"]
+		}
+	}`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+						"type":       "vxlan",
+						"vxlan.port": "4789",
+					}),
+				),
+			},
+			{
+				// The default port applied by Proxmox is read back as planned.
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					port := int32(4790)
+
+					err := te.ClusterClient().SDN().Zones().Update(context.Background(), zoneName, &zones.SdnZoneBody{
+						Name:      zoneName,
+						VxlanPort: &port,
+					})
+					if err != nil {
+						t.Fatalf("failed to update SDN zone %s: %s", zoneName, err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+						"vxlan.port": "4789",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSdnZoneDNSZoneRequiresDNS(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name    = "acc1"
+					dnszone = "example.com"
+					simple  = {}
+				}`),
+				ExpectError: regexp.MustCompile(`Attribute "dns" must be specified when "dnszone" is specified`),
+			},
+		},
+	})
+}
+
+func TestAccResourceSdnZoneListVNets(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	zoneName := fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999))
+	te.AddTemplateVars(map[string]any{
+		"ZoneName": zoneName,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name   = "{{.ZoneName}}"
+					simple = {}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_zone.test", []string{
+						"vnets",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name       = "{{.ZoneName}}"
+					list_vnets = true
+					simple     = {}
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+						"list_vnets": "true",
+						"vnets.#":    "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSdnZoneTypeChangeReplaces(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	zoneName := fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999))
+	te.AddTemplateVars(map[string]any{
+		"ZoneName": zoneName,
+	})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name = "{{.ZoneName}}"
+					vlan = {
+						bridge = "vmbr0"
+					}
+				}`),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+					"type":        "vlan",
+					"vlan.bridge": "vmbr0",
+				}),
+			},
+			{
+				// Changes within the same zone type are applied in place.
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name = "{{.ZoneName}}"
+					vlan = {
+						bridge = "vmbr1"
+					}
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("proxmox_virtual_environment_sdn_zone.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+					"type":        "vlan",
+					"vlan.bridge": "vmbr1",
+				}),
+			},
+			{
+				// Changes of the zone type replace the zone.
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name  = "{{.ZoneName}}"
+					vxlan = {
+						peers = ["10.0.0.1", "10.0.0.2"]
+					}
+				}`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("proxmox_virtual_environment_sdn_zone.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+						"type": "vxlan",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_zone.test", []string{
+						"vlan.bridge",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSdnZoneIPAMReplaceWithVNet(t *testing.T) {
+	te := test.InitEnvironment(t)
+
+	zoneName := fmt.Sprintf("acc%d", gofakeit.Number(1000, 99999))
+	vnetName := fmt.Sprintf("accv%d", gofakeit.Number(1000, 9999))
+	te.AddTemplateVars(map[string]any{
+		"ZoneName": zoneName,
+	})
+
+	config := func(ipam string) string {
+		return te.RenderConfig(fmt.Sprintf(`
+		resource "proxmox_virtual_environment_sdn_zone" "test" {
+			name                         = "{{.ZoneName}}"
+			ipam                         = %q
+			ipam_change_requires_replace = true
+			simple                       = {}
+		}`, ipam))
+	}
+
+	vnets := te.ClusterClient().SDN().VNets()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config("pve"),
+			},
+			{
+				// The provider doesn't manage VNets, so the replacement can't recreate them, and Proxmox
+				// refuses to delete a zone used by a VNet.
+				PreConfig: func() {
+					err := vnets.DoRequest(context.Background(), http.MethodPost, "cluster/sdn/vnets", &map[string]string{
+						"vnet": vnetName,
+						"zone": zoneName,
+					}, nil)
+					if err != nil {
+						t.Fatalf("failed to create SDN VNet %s: %s", vnetName, err)
+					}
+				},
+				Config: config(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("proxmox_virtual_environment_sdn_zone.test", plancheck.ResourceActionReplace),
+					},
+				},
+				ExpectError: regexp.MustCompile(`Error Deleting SDN Zone`),
+			},
+			{
+				// Once the VNet is removed, the zone is replaced.
+				PreConfig: func() {
+					err := vnets.DoRequest(context.Background(), http.MethodDelete, vnets.ExpandPath(vnetName), nil, nil)
+					if err != nil {
+						t.Fatalf("failed to delete SDN VNet %s: %s", vnetName, err)
+					}
+				},
+				Config: config(""),
+				Check: test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+					"ipam": "",
+				}),
+			},
+		},
+	})
+}
@@ -11,4 +11,7 @@ import "github.com/bpg/terraform-provider-proxmox/proxmox"
 // DataSource is the global configuration for all datasources.
 type DataSource struct {
 	Client proxmox.Client
+
+	// SDNStrict promotes the warnings of the SDN data sources to errors.
+	SDNStrict bool
 }
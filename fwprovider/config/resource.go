@@ -9,6 +9,7 @@ package config
 import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
 )
 
 // Resource is the global configuration for all resources.
@@ -16,4 +17,19 @@ type Resource struct {
 	Client proxmox.Client
 
 	IDGenerator cluster.IDGenerator
+
+	// SDNStrict promotes the warnings of the SDN resources to errors.
+	SDNStrict bool
+
+	// SDNAPIValidation enables the validations of the SDN resources that require extra API calls.
+	SDNAPIValidation bool
+
+	// SDNQuietNotFound removes the SDN objects deleted outside of Terraform from the state without a warning.
+	SDNQuietNotFound bool
+
+	// SDNDefaultIPAM is the IPAM of the SDN zones not setting one, empty to keep the built-in default.
+	SDNDefaultIPAM string
+
+	// SDNCapabilities holds the SDN features supported by the cluster, probed once per provider instance.
+	SDNCapabilities *sdn.CapabilitiesCache
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -30,6 +31,12 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/hardwaremapping"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/metrics"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/options"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn"
+	sdn_controllers "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/controllers"
+	sdn_dns "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/dns"
+	sdn_ipams "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/ipams"
+	sdn_status "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/status"
+	sdn_subnets "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/subnets"
 	sdn_zones "github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/sdn/zones"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes"
@@ -40,13 +47,17 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	proxmoxsdn "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
 	proxmoxnodes "github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
 	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ provider.Provider = &proxmoxProvider{}
+var (
+	_ provider.Provider              = &proxmoxProvider{}
+	_ provider.ProviderWithFunctions = &proxmoxProvider{}
+)
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
@@ -92,10 +103,14 @@ type proxmoxProviderModel struct {
 			Port    types.Int64  `tfsdk:"port"`
 		} `tfsdk:"node"`
 	} `tfsdk:"ssh"`
-	TmpDir         types.String `tfsdk:"tmp_dir"`
-	RandomVMIDs    types.Bool   `tfsdk:"random_vm_ids"`
-	RandomVMIDStat types.Int64  `tfsdk:"random_vm_id_start"`
-	RandomVMIDEnd  types.Int64  `tfsdk:"random_vm_id_end"`
+	TmpDir           types.String `tfsdk:"tmp_dir"`
+	RandomVMIDs      types.Bool   `tfsdk:"random_vm_ids"`
+	RandomVMIDStat   types.Int64  `tfsdk:"random_vm_id_start"`
+	RandomVMIDEnd    types.Int64  `tfsdk:"random_vm_id_end"`
+	SDNStrict        types.Bool   `tfsdk:"sdn_strict"`
+	SDNAPIValidation types.Bool   `tfsdk:"sdn_api_validation"`
+	SDNQuietNotFound types.Bool   `tfsdk:"sdn_quiet_not_found"`
+	SDNDefaultIPAM   types.String `tfsdk:"sdn_default_ipam"`
 }
 
 func (p *proxmoxProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -163,6 +178,35 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Validators:  []validator.Int64{int64validator.Between(100, 999999999)},
 			},
+			"sdn_api_validation": schema.BoolAttribute{
+				Description: "Whether to validate the SDN resources against the existing cluster " +
+					"configuration, e.g. that the bridge of a VLAN zone exists on the zone nodes. " +
+					"Requires extra API calls during planning. Failed validations are reported " +
+					"as warnings, unless `sdn_strict` is set, e.g. new SDN subnets overlapping with " +
+					"existing ones of the same zone, except for new EVPN zones colliding with existing " +
+					"ones, which are always errors. Defaults to `false`.",
+				Optional: true,
+			},
+			"sdn_default_ipam": schema.StringAttribute{
+				Description: "The IPAM of the SDN zones not setting `ipam` explicitly, overriding the " +
+					"built-in `pve` IPAM. Defaults to `pve`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"sdn_quiet_not_found": schema.BoolAttribute{
+				Description: "Whether to remove the SDN objects deleted outside of Terraform from the state " +
+					"silently, instead of reporting a warning when refreshing or deleting them. Defaults to `false`.",
+				Optional: true,
+			},
+			"sdn_strict": schema.BoolAttribute{
+				Description: "Whether to treat the warnings of the SDN resources and data sources, " +
+					"e.g. about SDN objects that vanished outside of Terraform, as errors. Failures of the extra API " +
+					"calls made only for validations or optional computed values, e.g. because of missing privileges, " +
+					"stay warnings. Defaults to `false`.",
+				Optional: true,
+			},
 			"tmp_dir": schema.StringAttribute{
 				Description: "The alternative temporary directory.",
 				Optional:    true,
@@ -490,10 +534,24 @@ func (p *proxmoxProvider) Configure(
 				RandomIDEnd:  int(cfg.RandomVMIDEnd.ValueInt64()),
 			},
 		),
+		SDNStrict:        cfg.SDNStrict.ValueBool(),
+		SDNAPIValidation: cfg.SDNAPIValidation.ValueBool(),
+		SDNQuietNotFound: cfg.SDNQuietNotFound.ValueBool(),
+		SDNDefaultIPAM:   cfg.SDNDefaultIPAM.ValueString(),
+		SDNCapabilities:  proxmoxsdn.NewCapabilitiesCache(client.Cluster().SDN()),
 	}
 
 	resp.DataSourceData = config.DataSource{
-		Client: client,
+		Client:    client,
+		SDNStrict: cfg.SDNStrict.ValueBool(),
+	}
+}
+
+func (p *proxmoxProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		sdn.NewListToStringFunction,
+		sdn.NewStringToListFunction,
+		sdn.NewCIDRAvailableFunction,
 	}
 }
 
@@ -515,6 +573,9 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 		network.NewLinuxVLANResource,
 		nodes.NewDownloadFileResource,
 		options.NewClusterOptionsResource,
+		sdn_controllers.NewSdnControllerResource,
+		sdn_ipams.NewSdnIpamMappingResource,
+		sdn_subnets.NewSdnSubnetResource,
 		sdn_zones.NewSdnZoneResource,
 		vm.NewResource,
 	}
@@ -539,6 +600,9 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		hardwaremapping.NewPCIDataSource,
 		hardwaremapping.NewUSBDataSource,
 		metrics.NewMetricsServerDatasource,
+		sdn_dns.NewSdnDnsDataSource,
+		sdn_status.NewSdnControllerStatusDataSource,
+		sdn_status.NewSdnStatusDataSource,
 		vm.NewDataSource,
 	}
 }
@@ -0,0 +1,81 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fwprovider_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+// TestSdnImportTargetsAreImportable checks that the import blocks generated from the SDN import targets
+// only reference resources of the provider supporting the import.
+func TestSdnImportTargetsAreImportable(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	// One object of every SDN type, so that every kind of import target is enumerated.
+	sections := map[string]any{
+		"controllers":         []map[string]any{{"controller": "evpn1", "type": "evpn"}},
+		"ipams":               []map[string]any{{"ipam": "pve", "type": "pve"}},
+		"dns":                 []map[string]any{{"dns": "dns1", "type": "powerdns"}},
+		"zones":               []map[string]any{{"zone": "zone1", "type": "evpn"}},
+		"vnets":               []map[string]any{{"vnet": "vnet1", "zone": "zone1"}},
+		"vnets/vnet1/subnets": []map[string]any{{"subnet": "zone1-10.0.0.0-24", "cidr": "10.0.0.0/24"}},
+	}
+
+	apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := sections[strings.Trim(strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/"), "/")]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+
+	targets, err := (&sdn.Client{Client: apiClient}).EnumerateForImport(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, targets)
+
+	p := fwprovider.New("test")()
+
+	metadataResp := &provider.MetadataResponse{}
+	p.Metadata(ctx, provider.MetadataRequest{}, metadataResp)
+
+	resources := map[string]resource.Resource{}
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+
+		resp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: metadataResp.TypeName}, resp)
+		resources[resp.TypeName] = r
+	}
+
+	for _, target := range targets {
+		if target.ListOnly {
+			continue
+		}
+
+		r, ok := resources[target.ResourceType]
+		require.True(t, ok, "%s is not a resource of the provider", target.ResourceType)
+
+		_, ok = r.(resource.ResourceWithImportState)
+		require.True(t, ok, "%s doesn't support the import", target.ResourceType)
+	}
+}
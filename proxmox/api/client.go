@@ -334,11 +334,17 @@ func validateResponseCode(res *http.Response) error {
 		errRes := &ErrorResponseBody{}
 		err := json.NewDecoder(res.Body).Decode(errRes)
 
+		var fieldErrors map[string]string
+
 		if err == nil && errRes.Errors != nil {
+			fieldErrors = make(map[string]string, len(*errRes.Errors))
+
 			var errList []string
 
-			for k, v := range *errRes.Errors {
-				errList = append(errList, fmt.Sprintf("%s: %s", k, strings.TrimRight(v, "\n\r")))
+			for _, k := range slices.Sorted(maps.Keys(*errRes.Errors)) {
+				v := strings.TrimRight((*errRes.Errors)[k], "\n\r")
+				fieldErrors[k] = v
+				errList = append(errList, fmt.Sprintf("%s: %s", k, v))
 			}
 
 			msg = fmt.Sprintf("%s (%s)", msg, strings.Join(errList, " - "))
@@ -347,6 +353,7 @@ func validateResponseCode(res *http.Response) error {
 		httpError := &HTTPError{
 			Code:    res.StatusCode,
 			Message: msg,
+			Errors:  fieldErrors,
 		}
 
 		if res.StatusCode == http.StatusNotFound ||
@@ -27,6 +27,9 @@ const ErrResourceDoesNotExist Error = "the requested resource does not exist"
 type HTTPError struct {
 	Code    int
 	Message string
+	// Errors holds the errors of the individual parameters rejected by Proxmox, by parameter name.
+	// They are also part of the message.
+	Errors map[string]string
 }
 
 func (err HTTPError) Error() string {
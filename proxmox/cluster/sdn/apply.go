@@ -0,0 +1,145 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// applyPollDelay is the delay between two reads of the log of the apply task when reporting its progress.
+const applyPollDelay = time.Second
+
+// reloadLogRegex matches the line of the apply task log reporting the reload of the network configuration of a node.
+var reloadLogRegex = regexp.MustCompile(`^([A-Za-z0-9.-]+): reloading network config`)
+
+// ApplyProgress is a progress update of a SDN apply.
+type ApplyProgress struct {
+	// Completed lists the nodes whose network configuration is reloaded, in the order they were reloaded.
+	Completed []string
+	// Reloading is the node whose network configuration is being reloaded, empty once the apply is finished.
+	Reloading string
+	// Finished is true once the apply task has completed successfully.
+	Finished bool
+}
+
+// Apply applies the pending SDN changes of the cluster and waits for the network configuration to be
+// reloaded. Proxmox always applies the SDN configuration to all nodes of the cluster, it has no way to
+// reconfigure only some of them.
+func (c *Client) Apply(ctx context.Context) error {
+	upid, err := c.StartApply(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.WaitForApply(ctx, upid, 0, nil)
+}
+
+// StartApply starts applying the pending SDN changes of the cluster, without waiting for the network
+// configuration of the nodes to be reloaded. It returns the ID of the apply task to pass to WaitForApply.
+func (c *Client) StartApply(ctx context.Context) (string, error) {
+	resBody := &ApplyResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPut, "cluster/sdn", nil, resBody)
+	if err != nil {
+		return "", fmt.Errorf("error applying SDN configuration: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return "", api.ErrNoDataObjectInResponse
+	}
+
+	return *resBody.Data, nil
+}
+
+// WaitForApply waits for the apply task to complete, at most for the timeout unless it's zero, and until the
+// context is canceled. The nodes are reloaded one after the other, so when the progress function is set, it's
+// called whenever the reload of another node starts, and once more when the apply is finished. The progress is
+// read from the task log, it isn't called when the apply fails.
+func (c *Client) WaitForApply(ctx context.Context, upid string, timeout time.Duration, progress func(ApplyProgress)) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if progress == nil {
+		err := c.Tasks().WaitForTask(ctx, upid)
+		if err != nil {
+			return fmt.Errorf("error applying SDN configuration: %w", err)
+		}
+
+		return nil
+	}
+
+	pollCtx, stopPolling := context.WithCancel(ctx)
+	defer stopPolling()
+
+	polled := make(chan struct{})
+
+	var reloaded []string
+
+	go func() {
+		defer close(polled)
+
+		for {
+			if nodes := c.reloadedNodes(pollCtx, upid); len(nodes) > len(reloaded) {
+				reloaded = nodes
+				progress(ApplyProgress{Completed: nodes[:len(nodes)-1], Reloading: nodes[len(nodes)-1]})
+			}
+
+			select {
+			case <-time.After(applyPollDelay):
+			case <-pollCtx.Done():
+				return
+			}
+		}
+	}()
+
+	err := c.Tasks().WaitForTask(ctx, upid)
+
+	stopPolling()
+	<-polled
+
+	if err != nil {
+		return fmt.Errorf("error applying SDN configuration: %w", err)
+	}
+
+	// All the nodes are reloaded once the task is completed, including the ones reloaded since the last poll.
+	if nodes := c.reloadedNodes(ctx, upid); len(nodes) > len(reloaded) {
+		reloaded = nodes
+	}
+
+	progress(ApplyProgress{Completed: reloaded, Finished: true})
+
+	return nil
+}
+
+// reloadedNodes returns the nodes whose reload is reported by the log of the apply task, in order. Failures to
+// read the log are ignored, as the log is only used to report the progress.
+func (c *Client) reloadedNodes(ctx context.Context, upid string) []string {
+	lines, err := c.Tasks().GetTaskLog(ctx, upid)
+	if err != nil {
+		return nil
+	}
+
+	var nodes []string
+
+	for _, line := range lines {
+		if match := reloadLogRegex.FindStringSubmatch(line); match != nil {
+			nodes = append(nodes, match[1])
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,149 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	const upid = "UPID:pve1:0000C4F2:0001A2B3:66000000:reloadnetworkall::root@pam:"
+
+	tests := []struct {
+		name     string
+		exitCode string
+		err      string
+	}{
+		{"applied", "OK", ""},
+		{"reload failed", "some nodes failed to reload", "failed to complete with exit code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+
+			apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				switch r.Method {
+				case http.MethodPut:
+					_, _ = fmt.Fprintf(w, `{"data":%q}`, upid)
+				default:
+					_, _ = fmt.Fprintf(w, `{"data":{"status":"stopped","exitstatus":%q}}`, tt.exitCode)
+				}
+			}))
+
+			client := &Client{Client: apiClient}
+
+			err := client.Apply(t.Context())
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, []string{
+				"PUT /api2/json/cluster/sdn",
+				"GET /api2/json/nodes/pve1/tasks/" + upid + "/status",
+			}, requests)
+		})
+	}
+}
+
+func TestWaitForApply(t *testing.T) {
+	t.Parallel()
+
+	const upid = "UPID:pve1:0000C4F2:0001A2B3:66000000:reloadnetworkall::root@pam:"
+
+	tests := []struct {
+		name string
+		// exitCode is the exit status of the task once stopped, the task never stops when it's empty.
+		exitCode string
+		timeout  time.Duration
+		err      string
+	}{
+		{"applied", "OK", 0, ""},
+		{"reload failed", "some nodes failed to reload", 0, "failed to complete with exit code"},
+		{"timed out", "", 100 * time.Millisecond, "timeout while waiting for task"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the task runs until its status is read twice, reloading a node at every read
+			var statusReads atomic.Int32
+
+			apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/status"):
+					if statusReads.Add(1) < 2 || tt.exitCode == "" {
+						_, _ = w.Write([]byte(`{"data":{"status":"running"}}`))
+					} else {
+						_, _ = fmt.Fprintf(w, `{"data":{"status":"stopped","exitstatus":%q}}`, tt.exitCode)
+					}
+				case strings.HasSuffix(r.URL.Path, "/log"):
+					lines := []string{`{"n":1,"t":"pve1: reloading network config"}`}
+					if statusReads.Load() >= 2 {
+						lines = append(lines, `{"n":2,"t":"pve2: reloading network config"}`, `{"n":3,"t":"TASK OK"}`)
+					}
+
+					_, _ = fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(lines, ","))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+
+			client := &Client{Client: apiClient}
+
+			var (
+				mu      sync.Mutex
+				updates []ApplyProgress
+			)
+
+			err := client.WaitForApply(t.Context(), upid, tt.timeout, func(progress ApplyProgress) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				updates = append(updates, progress)
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+
+				for _, update := range updates {
+					require.False(t, update.Finished)
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, updates)
+			require.Empty(t, updates[0].Completed)
+			require.Equal(t, "pve1", updates[0].Reloading)
+			require.Equal(t, ApplyProgress{Completed: []string{"pve1", "pve2"}, Finished: true}, updates[len(updates)-1])
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
+)
+
+// zoneTypesMinVersion is the first major Proxmox VE version known to support all the zoneTypes.
+// The zone types supported by older versions are unknown.
+const zoneTypesMinVersion = 7
+
+// zoneTypes lists the SDN zone types supported by the Proxmox VE versions since zoneTypesMinVersion.
+var zoneTypes = []string{"evpn", "qinq", "simple", "vlan", "vxlan"}
+
+// Capabilities describes the SDN features supported by a Proxmox cluster.
+type Capabilities struct {
+	// Available is false when SDN is not installed or not enabled on the cluster.
+	Available bool
+	// ZoneTypes lists the zone types accepted by the cluster, sorted by name, or nil if they are unknown.
+	ZoneTypes []string
+}
+
+// SupportsZoneType returns whether the cluster accepts SDN zones of the given type. When the supported
+// zone types are unknown, all types are assumed to be supported and left to Proxmox to reject.
+func (c *Capabilities) SupportsZoneType(zoneType string) bool {
+	return c.ZoneTypes == nil || slices.Contains(c.ZoneTypes, zoneType)
+}
+
+// Capabilities probes the SDN features supported by the cluster. A cluster without SDN is not an error,
+// it's reported by Available being false. The supported zone types are derived from the Proxmox VE
+// version of the cluster, and are unknown when the version can't be parsed or is too old to be known.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	_, err := c.Zones().List(ctx)
+	if errors.Is(err, zones.ErrSDNNotAvailable) {
+		return &Capabilities{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error probing the SDN capabilities: %w", err)
+	}
+
+	versionData, err := (&version.Client{Client: c.Client}).Version(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error probing the SDN capabilities: %w", err)
+	}
+
+	capabilities := &Capabilities{Available: true}
+
+	if major, ok := majorVersion(versionData.Version); ok && major >= zoneTypesMinVersion {
+		capabilities.ZoneTypes = slices.Clone(zoneTypes)
+	}
+
+	return capabilities, nil
+}
+
+// majorVersion returns the major version of a Proxmox VE version, e.g. 8 for "8.2.4".
+func majorVersion(v string) (int, bool) {
+	major, _, _ := strings.Cut(v, ".")
+
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// CapabilitiesCache probes the SDN capabilities of a cluster on first use and returns the same result
+// afterwards, so that a provider instance probes them only once. Failed probes are retried on the next use.
+type CapabilitiesCache struct {
+	client *Client
+
+	mu           sync.Mutex
+	capabilities *Capabilities
+}
+
+// NewCapabilitiesCache creates a cache of the SDN capabilities of the cluster of the client.
+func NewCapabilitiesCache(client *Client) *CapabilitiesCache {
+	return &CapabilitiesCache{client: client}
+}
+
+// Get returns the SDN capabilities of the cluster, probing them on the first call.
+func (c *CapabilitiesCache) Get(ctx context.Context) (*Capabilities, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capabilities != nil {
+		return c.capabilities, nil
+	}
+
+	capabilities, err := c.client.Capabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.capabilities = capabilities
+
+	return capabilities, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestCapabilitiesCache(t *testing.T) {
+	t.Parallel()
+
+	// version answers the version request with the given Proxmox VE version, and the zones list request with an empty list.
+	version := func(v string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api2/json/version" {
+				_, _ = w.Write([]byte(`{"data":{"version":"` + v + `","release":"` + v + `"}}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		requests     int32
+		capabilities *Capabilities
+	}{
+		{"known version", version("8.2.4"), 2,
+			&Capabilities{Available: true, ZoneTypes: []string{"evpn", "qinq", "simple", "vlan", "vxlan"}}},
+		// The zone types of an older or unparsable version are unknown, and left to Proxmox to validate.
+		{"older version", version("6.4-1"), 2, &Capabilities{Available: true}},
+		{"unparsable version", version("pve"), 2, &Capabilities{Available: true}},
+		{"SDN not available", func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "", http.StatusNotImplemented)
+		}, 1, &Capabilities{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+
+			apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				tt.handler(w, r)
+			}))
+
+			cache := NewCapabilitiesCache(&Client{Client: apiClient})
+
+			for range 2 {
+				capabilities, err := cache.Get(t.Context())
+				require.NoError(t, err)
+				require.Equal(t, tt.capabilities, capabilities)
+			}
+
+			require.Equal(t, tt.requests, requests.Load(), "the capabilities must be probed only once")
+		})
+	}
+}
+
+func TestSupportsZoneType(t *testing.T) {
+	t.Parallel()
+
+	known := &Capabilities{Available: true, ZoneTypes: []string{"simple", "vlan"}}
+	require.True(t, known.SupportsZoneType("vlan"))
+	require.False(t, known.SupportsZoneType("evpn"))
+
+	unknown := &Capabilities{Available: true}
+	require.True(t, unknown.SupportsZoneType("evpn"))
+}
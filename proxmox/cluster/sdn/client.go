@@ -10,7 +10,13 @@ import (
 	"fmt"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/controllers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/dns"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/ipams"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/subnets"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/vnets"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
 )
 
 // Client is an interface for accessing the Proxmox ACME API.
@@ -24,6 +30,36 @@ func (c *Client) ExpandPath(path string) string {
 }
 
 // Zones returns a client for managing the cluster's SDN zones.
-func (c *Client) Zones() *zones.Client {
-	return &zones.Client{Client: c.Client}
+func (c *Client) Zones(opts ...zones.ClientOption) *zones.Client {
+	return zones.NewClient(c.Client, opts...)
+}
+
+// Controllers returns a client for managing the cluster's SDN controllers.
+func (c *Client) Controllers() *controllers.Client {
+	return &controllers.Client{Client: c.Client}
+}
+
+// DNS returns a client for managing the cluster's SDN DNS servers.
+func (c *Client) DNS() *dns.Client {
+	return &dns.Client{Client: c.Client}
+}
+
+// IPAMs returns a client for managing the cluster's SDN IPAMs.
+func (c *Client) IPAMs() *ipams.Client {
+	return &ipams.Client{Client: c.Client}
+}
+
+// Subnets returns a client for managing the cluster's SDN subnets.
+func (c *Client) Subnets() *subnets.Client {
+	return &subnets.Client{Client: c.Client}
+}
+
+// VNets returns a client for managing the cluster's SDN VNets.
+func (c *Client) VNets() *vnets.Client {
+	return &vnets.Client{Client: c.Client}
+}
+
+// Tasks returns the generic node tasks client, used to follow the SDN apply tasks.
+func (c *Client) Tasks() *tasks.Client {
+	return &tasks.Client{Client: c.Client}
 }
@@ -0,0 +1,83 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// List returns a list of SDN controllers in the Proxmox cluster.
+func (c *Client) List(ctx context.Context) ([]*SdnControllerBody, error) {
+	resBody := &SdnControllerListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN controllers: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].Name < resBody.Data[j].Name
+	})
+
+	return resBody.Data, nil
+}
+
+// Get retrieves a single SDN controller based on its identifier.
+func (c *Client) Get(ctx context.Context, controller string) (*SdnControllerBody, error) {
+	resBody := &SdnControllerGetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(controller)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN controller: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// Create creates a new SDN controller.
+func (c *Client) Create(ctx context.Context, data *SdnControllerBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN controller: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing SDN controller.
+func (c *Client) Update(ctx context.Context, controller string, data *SdnControllerBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(controller)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN controller: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an SDN controller.
+func (c *Client) Delete(ctx context.Context, controller string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(controller)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN controller: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package controllers
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+
+// SdnControllerListResponseBody contains the body from a SDN controllers list response.
+type SdnControllerListResponseBody struct {
+	Data []*SdnControllerBody `json:"data,omitempty"`
+}
+
+// SdnControllerGetResponseBody contains the body from a SDN controller get response.
+type SdnControllerGetResponseBody struct {
+	Data *SdnControllerBody `json:"data,omitempty"`
+}
+
+// SdnControllerBody represents the body of a SDN controller in Proxmox.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/controllers
+type SdnControllerBody struct {
+	Name string `json:"controller" url:"controller"`
+
+	Type                    *string           `json:"type,omitempty" url:"type,omitempty"`     // Should be omitted only with update requests.
+	Delete                  *string           `json:"delete,omitempty" url:"delete,omitempty"` // Should be used only with update requests.
+	Asn                     *int64            `json:"asn,omitempty" url:"asn,omitempty"`
+	BgpMultipathAsPathRelax *types.CustomBool `json:"bgp-multipath-as-path-relax,omitempty" url:"bgp-multipath-as-path-relax,omitempty,int"`
+	Digest                  *string           `json:"digest,omitempty" url:"digest,omitempty"`
+	Ebgp                    *types.CustomBool `json:"ebgp,omitempty" url:"ebgp,omitempty,int"`
+	EbgpMultihop            *int32            `json:"ebgp-multihop,omitempty" url:"ebgp-multihop,omitempty"`
+	IsisDomain              *string           `json:"isis-domain,omitempty" url:"isis-domain,omitempty"`
+	IsisIfaces              *string           `json:"isis-ifaces,omitempty" url:"isis-ifaces,omitempty"`
+	IsisNet                 *string           `json:"isis-net,omitempty" url:"isis-net,omitempty"`
+	Loopback                *string           `json:"loopback,omitempty" url:"loopback,omitempty"`
+	Node                    *string           `json:"node,omitempty" url:"node,omitempty"`
+	Peers                   *string           `json:"peers,omitempty" url:"peers,omitempty"`
+}
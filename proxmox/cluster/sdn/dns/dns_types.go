@@ -0,0 +1,30 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package dns
+
+// SdnDnsListResponseBody contains the body from a SDN DNS servers list response.
+type SdnDnsListResponseBody struct {
+	Data []*SdnDnsBody `json:"data,omitempty"`
+}
+
+// SdnDnsGetResponseBody contains the body from a SDN DNS server get response.
+type SdnDnsGetResponseBody struct {
+	Data *SdnDnsBody `json:"data,omitempty"`
+}
+
+// SdnDnsBody represents the body of a SDN DNS server in Proxmox.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/dns
+type SdnDnsBody struct {
+	Name string `json:"dns" url:"dns"`
+
+	Type          *string `json:"type,omitempty" url:"type,omitempty"`
+	Digest        *string `json:"digest,omitempty" url:"digest,omitempty"`
+	Fingerprint   *string `json:"fingerprint,omitempty" url:"fingerprint,omitempty"`
+	ReverseMaskV6 *int32  `json:"reversemaskv6,omitempty" url:"reversemaskv6,omitempty"`
+	TTL           *int32  `json:"ttl,omitempty" url:"ttl,omitempty"`
+	URL           *string `json:"url,omitempty" url:"url,omitempty"`
+}
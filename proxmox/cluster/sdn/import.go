@@ -0,0 +1,144 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/vnets"
+)
+
+// Resource types of the SDN objects returned by EnumerateForImport.
+const (
+	ResourceTypeZone       = "proxmox_virtual_environment_sdn_zone"
+	ResourceTypeVNet       = "proxmox_virtual_environment_sdn_vnet"
+	ResourceTypeSubnet     = "proxmox_virtual_environment_sdn_subnet"
+	ResourceTypeController = "proxmox_virtual_environment_sdn_controller"
+)
+
+// ImportTarget identifies an existing SDN object that can be imported into Terraform.
+type ImportTarget struct {
+	// ResourceType is the Terraform resource type managing the object.
+	ResourceType string
+	// ImportID is the identifier to pass to `terraform import`.
+	ImportID string
+	// ListOnly is set for the objects without a resource in the provider, which can only be listed.
+	ListOnly bool
+}
+
+// EnumerateForImport lists all SDN objects of the cluster as import targets. The objects are
+// ordered so that every object comes after the objects it depends on: controllers first, then zones,
+// VNets and finally subnets, whose import ID is `<vnet>/<subnet>`. IPAMs and DNS servers have no
+// resource in the provider, so they are not listed, and VNets are only listed.
+func (c *Client) EnumerateForImport(ctx context.Context) ([]ImportTarget, error) {
+	var targets []ImportTarget
+
+	add := func(resourceType string, importID string) {
+		targets = append(targets, ImportTarget{ResourceType: resourceType, ImportID: importID})
+	}
+
+	controllerList, err := c.Controllers().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating SDN controllers: %w", err)
+	}
+
+	for _, controller := range controllerList {
+		add(ResourceTypeController, controller.Name)
+	}
+
+	zoneList, err := c.Zones().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating SDN zones: %w", err)
+	}
+
+	for _, zone := range zoneList {
+		add(ResourceTypeZone, zone.Name)
+	}
+
+	vnetList, err := c.VNets().ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating SDN VNets: %w", err)
+	}
+
+	vnetTargets, err := c.enumerateVNets(ctx, vnetList)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(targets, vnetTargets...), nil
+}
+
+// EnumerateZoneForImport lists the VNets of a SDN zone and their subnets as import targets, in the same
+// order as EnumerateForImport. The zone itself is not included.
+func (c *Client) EnumerateZoneForImport(ctx context.Context, zone string) ([]ImportTarget, error) {
+	vnetList, err := c.VNets().ListByZone(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating SDN VNets of zone %s: %w", zone, err)
+	}
+
+	return c.enumerateVNets(ctx, vnetList)
+}
+
+// enumerateVNets lists the VNets and then their subnets as import targets.
+func (c *Client) enumerateVNets(ctx context.Context, vnetList []*vnets.SdnVnetBody) ([]ImportTarget, error) {
+	targets := make([]ImportTarget, 0, len(vnetList))
+
+	for _, vnet := range vnetList {
+		targets = append(targets, ImportTarget{ResourceType: ResourceTypeVNet, ImportID: vnet.Name, ListOnly: true})
+	}
+
+	for _, vnet := range vnetList {
+		subnetList, err := c.Subnets().List(ctx, vnet.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error enumerating SDN subnets of VNet %s: %w", vnet.Name, err)
+		}
+
+		for _, subnet := range subnetList {
+			targets = append(targets, ImportTarget{ResourceType: ResourceTypeSubnet, ImportID: vnet.Name + "/" + subnet.Subnet})
+		}
+	}
+
+	return targets, nil
+}
+
+// ImportBlocks formats the import targets as Terraform `import` blocks, skipping the list-only targets. The
+// resources are named after their import IDs, with the characters not allowed in resource names replaced
+// by underscores.
+func ImportBlocks(targets []ImportTarget) string {
+	blocks := make([]string, 0, len(targets))
+
+	for _, target := range targets {
+		if target.ListOnly {
+			continue
+		}
+
+		blocks = append(blocks, fmt.Sprintf("import {\n  to = %s.%s\n  id = %q\n}\n",
+			target.ResourceType, resourceName(target.ImportID), target.ImportID))
+	}
+
+	return strings.Join(blocks, "\n")
+}
+
+// resourceName derives a valid Terraform resource name from an import ID.
+func resourceName(importID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+
+		return '_'
+	}, importID)
+
+	if name == "" || !unicode.IsLetter(rune(name[0])) && name[0] != '_' {
+		name = "_" + name
+	}
+
+	return name
+}
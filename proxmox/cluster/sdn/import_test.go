@@ -0,0 +1,105 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+// newImportTestClient creates a client connected to a test server serving the SDN configuration of a cluster.
+func newImportTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	sections := map[string]any{
+		"controllers": []map[string]any{{"controller": "evpn1", "type": "evpn"}},
+		"zones":       []map[string]any{{"zone": "zone1", "type": "evpn"}, {"zone": "zone2", "type": "simple"}},
+		"vnets": []map[string]any{
+			{"vnet": "vnet1", "zone": "zone1"},
+			{"vnet": "vnet2", "zone": "zone1"},
+			{"vnet": "vnet3", "zone": "zone2"},
+		},
+		"vnets/vnet1/subnets": []map[string]any{{"subnet": "zone1-10.0.0.0-24", "cidr": "10.0.0.0/24"}},
+		"vnets/vnet2/subnets": []map[string]any{},
+		"vnets/vnet3/subnets": []map[string]any{},
+	}
+
+	apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := sections[strings.Trim(strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/"), "/")]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+
+	return &Client{Client: apiClient}
+}
+
+func TestEnumerateForImport(t *testing.T) {
+	t.Parallel()
+
+	client := newImportTestClient(t)
+
+	targets, err := client.EnumerateForImport(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []ImportTarget{
+		{ResourceType: ResourceTypeController, ImportID: "evpn1"},
+		{ResourceType: ResourceTypeZone, ImportID: "zone1"},
+		{ResourceType: ResourceTypeZone, ImportID: "zone2"},
+		{ResourceType: ResourceTypeVNet, ImportID: "vnet1", ListOnly: true},
+		{ResourceType: ResourceTypeVNet, ImportID: "vnet2", ListOnly: true},
+		{ResourceType: ResourceTypeVNet, ImportID: "vnet3", ListOnly: true},
+		{ResourceType: ResourceTypeSubnet, ImportID: "vnet1/zone1-10.0.0.0-24"},
+	}, targets)
+}
+
+func TestEnumerateZoneForImport(t *testing.T) {
+	t.Parallel()
+
+	client := newImportTestClient(t)
+
+	targets, err := client.EnumerateZoneForImport(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, []ImportTarget{
+		{ResourceType: ResourceTypeVNet, ImportID: "vnet1", ListOnly: true},
+		{ResourceType: ResourceTypeVNet, ImportID: "vnet2", ListOnly: true},
+		{ResourceType: ResourceTypeSubnet, ImportID: "vnet1/zone1-10.0.0.0-24"},
+	}, targets)
+
+	targets, err = client.EnumerateZoneForImport(t.Context(), "zone3")
+	require.NoError(t, err)
+	require.Empty(t, targets)
+}
+
+func TestImportBlocks(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `import {
+  to = proxmox_virtual_environment_sdn_zone.zone1
+  id = "zone1"
+}
+
+import {
+  to = proxmox_virtual_environment_sdn_subnet.vnet1_zone1-10_0_0_0-24
+  id = "vnet1/zone1-10.0.0.0-24"
+}
+`, ImportBlocks([]ImportTarget{
+		{ResourceType: ResourceTypeZone, ImportID: "zone1"},
+		{ResourceType: ResourceTypeVNet, ImportID: "vnet1", ListOnly: true},
+		{ResourceType: ResourceTypeSubnet, ImportID: "vnet1/zone1-10.0.0.0-24"},
+	}))
+
+	require.Equal(t, "_1vnet", resourceName("1vnet"))
+}
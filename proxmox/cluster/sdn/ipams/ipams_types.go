@@ -0,0 +1,44 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ipams
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// DefaultIPAM is the built-in Proxmox IPAM.
+const DefaultIPAM = "pve"
+
+// SdnIpamListResponseBody contains the body from a SDN IPAM list response.
+type SdnIpamListResponseBody struct {
+	Data []*SdnIpamBody `json:"data,omitempty"`
+}
+
+// SdnIpamBody represents a SDN IPAM.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/ipams
+type SdnIpamBody struct {
+	Name string  `json:"ipam"`
+	Type *string `json:"type,omitempty"`
+}
+
+// SdnIpamStatusResponseBody contains the body from a SDN IPAM status response.
+type SdnIpamStatusResponseBody struct {
+	Data []*SdnIpamStatusEntry `json:"data,omitempty"`
+}
+
+// SdnIpamStatusEntry represents an entry registered in a SDN IPAM.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/ipams/{ipam}/status
+type SdnIpamStatusEntry struct {
+	Zone     string             `json:"zone"`
+	VNet     string             `json:"vnet"`
+	Subnet   string             `json:"subnet"`
+	IP       string             `json:"ip"`
+	MAC      *string            `json:"mac,omitempty"`
+	VMID     *types.CustomInt64 `json:"vmid,omitempty"`
+	Hostname *string            `json:"hostname,omitempty"`
+	Gateway  *types.CustomBool  `json:"gateway,omitempty"`
+}
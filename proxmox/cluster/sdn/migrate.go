@@ -0,0 +1,153 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/vnets"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/zones"
+)
+
+// MigrateZoneOption is an option for migrating a SDN zone.
+type MigrateZoneOption interface {
+	apply(opts *migrateZoneOptions)
+}
+
+type migrateZoneOptions struct {
+	dryRun bool
+}
+
+type withDryRun struct{}
+
+// WithDryRun is an option to only plan the migration of a SDN zone, without modifying anything.
+func WithDryRun() MigrateZoneOption {
+	return withDryRun{}
+}
+
+func (w withDryRun) apply(opts *migrateZoneOptions) {
+	opts.dryRun = true
+}
+
+// ZoneMigration describes the migration of a SDN zone to a new name.
+type ZoneMigration struct {
+	// From is the current name of the zone.
+	From string
+	// To is the new name of the zone.
+	To string
+	// Zone is the configuration the zone is created with under the new name.
+	Zone *zones.SdnZoneBody
+	// VNets lists the VNets moved from the old zone to the new one.
+	VNets []string
+}
+
+// MigrateZone renames a SDN zone while preserving its topology. As Proxmox can't rename zones,
+// a new zone is created with the configuration of the old one, the VNets of the old zone are moved
+// to the new one, and the old zone is deleted. If a step fails, the already done steps are reverted.
+// The changes are pending until the SDN configuration is applied.
+func (c *Client) MigrateZone(
+	ctx context.Context,
+	oldName, newName string,
+	opts ...MigrateZoneOption,
+) (*ZoneMigration, error) {
+	options := &migrateZoneOptions{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	zone, err := c.Zones().Get(ctx, oldName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN zone %s: %w", oldName, err)
+	}
+
+	_, err = c.Zones().Get(ctx, newName)
+	if err == nil {
+		return nil, fmt.Errorf("SDN zone %s already exists", newName)
+	}
+
+	if !errors.Is(err, api.ErrResourceDoesNotExist) {
+		return nil, fmt.Errorf("error reading SDN zone %s: %w", newName, err)
+	}
+
+	list, err := c.VNets().ListByZone(ctx, oldName)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN VNets of zone %s: %w", oldName, err)
+	}
+
+	zone.Name = newName
+	zone.Digest = nil
+
+	migration := &ZoneMigration{
+		From:  oldName,
+		To:    newName,
+		Zone:  zone,
+		VNets: make([]string, 0, len(list)),
+	}
+
+	for _, vnet := range list {
+		migration.VNets = append(migration.VNets, vnet.Name)
+	}
+
+	if options.dryRun {
+		return migration, nil
+	}
+
+	err = c.Zones().Create(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SDN zone %s: %w", newName, err)
+	}
+
+	for i, vnet := range migration.VNets {
+		err = c.moveVNet(ctx, vnet, newName)
+		if err != nil {
+			err = fmt.Errorf("error moving SDN VNet %s to zone %s: %w", vnet, newName, err)
+
+			return nil, errors.Join(err, c.revertZoneMigration(ctx, migration, migration.VNets[:i]))
+		}
+	}
+
+	err = c.Zones().Delete(ctx, oldName)
+	if err != nil {
+		err = fmt.Errorf("error deleting SDN zone %s: %w", oldName, err)
+
+		return nil, errors.Join(err, c.revertZoneMigration(ctx, migration, migration.VNets))
+	}
+
+	return migration, nil
+}
+
+func (c *Client) moveVNet(ctx context.Context, vnet, zone string) error {
+	return c.VNets().Update(ctx, vnet, &vnets.SdnVnetBody{
+		Name: vnet,
+		Zone: &zone,
+	})
+}
+
+// revertZoneMigration moves the already moved VNets back to the old zone and deletes the new zone.
+func (c *Client) revertZoneMigration(ctx context.Context, migration *ZoneMigration, moved []string) error {
+	var errs []error
+
+	for _, vnet := range moved {
+		err := c.moveVNet(ctx, vnet, migration.From)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error moving SDN VNet %s back to zone %s: %w", vnet, migration.From, err))
+		}
+	}
+
+	// The new zone can't be deleted while it still holds any VNet.
+	if len(errs) == 0 {
+		err := c.Zones().Delete(ctx, migration.To)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error deleting SDN zone %s: %w", migration.To, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
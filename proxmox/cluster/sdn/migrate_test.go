@@ -0,0 +1,149 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+// migrationServer is a minimal in-memory SDN API holding zones and the zones of VNets.
+type migrationServer struct {
+	mu        sync.Mutex
+	zones     map[string]string
+	vnets     map[string]string
+	failVNets map[string]bool
+	requests  []string
+}
+
+func (s *migrationServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/")
+	s.requests = append(s.requests, r.Method+" "+path)
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var data any
+
+	switch section, name, _ := strings.Cut(path, "/"); {
+	case section == "zones" && r.Method == http.MethodGet:
+		zoneType, ok := s.zones[name]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		data = map[string]any{"zone": name, "type": zoneType, "digest": "abc"}
+	case section == "zones" && r.Method == http.MethodPost:
+		s.zones[r.PostForm.Get("zone")] = r.PostForm.Get("type")
+	case section == "zones" && r.Method == http.MethodDelete:
+		delete(s.zones, name)
+	case section == "vnets" && r.Method == http.MethodGet:
+		list := []map[string]any{}
+		for vnet, zone := range s.vnets {
+			list = append(list, map[string]any{"vnet": vnet, "zone": zone})
+		}
+
+		data = list
+	case section == "vnets" && r.Method == http.MethodPut:
+		if s.failVNets[name] {
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.vnets[name] = r.PostForm.Get("zone")
+	default:
+		http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, path), http.StatusNotImplemented)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func newMigrationClient(t *testing.T, s *migrationServer) *Client {
+	t.Helper()
+
+	return &Client{Client: sdntest.NewAPIClient(t, http.HandlerFunc(s.handle))}
+}
+
+func newMigrationServer() *migrationServer {
+	return &migrationServer{
+		zones:     map[string]string{"old": "vlan", "other": "simple"},
+		vnets:     map[string]string{"vnet1": "old", "vnet2": "old", "vnet3": "other"},
+		failVNets: map[string]bool{},
+	}
+}
+
+func TestMigrateZone(t *testing.T) {
+	t.Parallel()
+
+	s := newMigrationServer()
+	client := newMigrationClient(t, s)
+
+	migration, err := client.MigrateZone(t.Context(), "old", "new")
+	require.NoError(t, err)
+	require.Equal(t, []string{"vnet1", "vnet2"}, migration.VNets)
+	require.Nil(t, migration.Zone.Digest)
+
+	require.Equal(t, map[string]string{"new": "vlan", "other": "simple"}, s.zones)
+	require.Equal(t, map[string]string{"vnet1": "new", "vnet2": "new", "vnet3": "other"}, s.vnets)
+}
+
+func TestMigrateZoneDryRun(t *testing.T) {
+	t.Parallel()
+
+	s := newMigrationServer()
+	client := newMigrationClient(t, s)
+
+	migration, err := client.MigrateZone(t.Context(), "old", "new", WithDryRun())
+	require.NoError(t, err)
+	require.Equal(t, "new", migration.Zone.Name)
+	require.Equal(t, []string{"vnet1", "vnet2"}, migration.VNets)
+
+	for _, req := range s.requests {
+		require.True(t, strings.HasPrefix(req, http.MethodGet), "unexpected request in dry-run: %s", req)
+	}
+
+	require.Equal(t, map[string]string{"old": "vlan", "other": "simple"}, s.zones)
+}
+
+func TestMigrateZoneExistingTarget(t *testing.T) {
+	t.Parallel()
+
+	s := newMigrationServer()
+	client := newMigrationClient(t, s)
+
+	_, err := client.MigrateZone(t.Context(), "old", "other")
+	require.ErrorContains(t, err, "SDN zone other already exists")
+}
+
+func TestMigrateZoneRevertsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	s := newMigrationServer()
+	s.failVNets["vnet2"] = true
+	client := newMigrationClient(t, s)
+
+	_, err := client.MigrateZone(t.Context(), "old", "new")
+	require.ErrorContains(t, err, "error moving SDN VNet vnet2 to zone new")
+
+	require.Equal(t, map[string]string{"old": "vlan", "other": "simple"}, s.zones)
+	require.Equal(t, map[string]string{"vnet1": "old", "vnet2": "old", "vnet3": "other"}, s.vnets)
+}
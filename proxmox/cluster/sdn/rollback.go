@@ -0,0 +1,25 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Rollback discards the pending SDN changes of the cluster, restoring the configuration that was last
+// applied. Changes of zones, VNets, subnets, controllers, IPAMs and DNS servers are all rolled back,
+// including the ones not made by this client. Requires Proxmox VE 8.3 or later.
+func (c *Client) Rollback(ctx context.Context) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("rollback"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error rolling back pending SDN changes: %w", err)
+	}
+
+	return nil
+}
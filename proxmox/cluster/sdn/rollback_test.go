@@ -0,0 +1,59 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		code int
+		err  bool
+	}{
+		{"rolled back", http.StatusOK, false},
+		{"not supported", http.StatusNotImplemented, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests []string
+
+			apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				if tt.code != http.StatusOK {
+					http.Error(w, "", tt.code)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"data":null}`))
+			}))
+
+			client := &Client{Client: apiClient}
+
+			err := client.Rollback(t.Context())
+			if tt.err {
+				require.ErrorContains(t, err, "error rolling back pending SDN changes")
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, []string{"POST /api2/json/cluster/sdn/rollback"}, requests)
+		})
+	}
+}
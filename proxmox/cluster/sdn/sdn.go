@@ -0,0 +1,91 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+// configSections lists the SDN configuration sections covered by the cluster-wide digest. Subnets have
+// their own configuration file too, but are listed per VNet, see subnetsDigest.
+var configSections = []string{"controllers", "dns", "ipams", "vnets", "zones"}
+
+// ConfigDigest returns a digest of the whole SDN configuration of the cluster.
+// Every SDN configuration section carries its own digest, the returned value combines
+// all of them, so it changes whenever any zone, VNet, subnet, controller, IPAM or DNS entry is modified.
+func (c *Client) ConfigDigest(ctx context.Context) (string, error) {
+	hash := sha256.New()
+
+	for _, section := range configSections {
+		digest, err := c.sectionDigest(ctx, section)
+		if err != nil {
+			return "", fmt.Errorf("error reading SDN %s configuration: %w", section, err)
+		}
+
+		fmt.Fprintf(hash, "%s:%s\n", section, digest)
+	}
+
+	digest, err := c.subnetsDigest(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(hash, "subnets:%s\n", digest)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sectionDigest returns the digest of the configuration file of the SDN section.
+func (c *Client) sectionDigest(ctx context.Context, section string) (string, error) {
+	resBody := &SectionListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(section), nil, resBody)
+	if err != nil {
+		return "", err
+	}
+
+	if resBody.Data == nil {
+		return "", api.ErrNoDataObjectInResponse
+	}
+
+	// All entries of a section share the digest of the section's configuration file,
+	// an empty section has no digest at all.
+	if len(resBody.Data) == 0 {
+		return "", nil
+	}
+
+	return resBody.Data[0].Digest, nil
+}
+
+// subnetsDigest returns the digest of the subnets configuration. Subnets are only listed per VNet,
+// but all of them share the same configuration file, so the digest of the first VNet with subnets is used.
+func (c *Client) subnetsDigest(ctx context.Context) (string, error) {
+	vnetList, err := c.VNets().ListAll(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error reading SDN vnets configuration: %w", err)
+	}
+
+	for _, vnet := range vnetList {
+		subnetList, err := c.Subnets().List(ctx, vnet.Name)
+		if err != nil {
+			return "", fmt.Errorf("error reading SDN subnets configuration: %w", err)
+		}
+
+		if len(subnetList) > 0 {
+			return ptr.Or(subnetList[0].Digest, ""), nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,91 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestConfigDigest(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+
+	// Every entry carries the digest of its section's configuration file.
+	sections := map[string]any{
+		"controllers":         []map[string]any{},
+		"dns":                 []map[string]any{},
+		"ipams":               []map[string]any{{"ipam": "pve", "digest": "ipams1"}},
+		"zones":               []map[string]any{{"zone": "zone1", "digest": "zones1"}},
+		"vnets":               []map[string]any{{"vnet": "vnet1", "digest": "vnets1"}, {"vnet": "vnet2", "digest": "vnets1"}},
+		"vnets/vnet1/subnets": []map[string]any{},
+		"vnets/vnet2/subnets": []map[string]any{{"subnet": "zone1-10.0.0.0-24", "cidr": "10.0.0.0/24", "digest": "subnets1"}},
+	}
+
+	client := &Client{Client: sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		data, ok := sections[strings.Trim(strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/"), "/")]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))}
+
+	set := func(section string, data []map[string]any) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sections[section] = data
+	}
+
+	initial, err := client.ConfigDigest(t.Context())
+	require.NoError(t, err)
+	require.Len(t, initial, 64)
+
+	again, err := client.ConfigDigest(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, initial, again, "an unchanged configuration must have the same digest")
+
+	// A subnet-only change modifies the subnets configuration file only.
+	set("vnets/vnet2/subnets", []map[string]any{{"subnet": "zone1-10.0.0.0-24", "cidr": "10.0.0.0/24", "digest": "subnets2"}})
+
+	subnetChanged, err := client.ConfigDigest(t.Context())
+	require.NoError(t, err)
+	require.NotEqual(t, initial, subnetChanged)
+
+	set("zones", []map[string]any{{"zone": "zone1", "digest": "zones2"}})
+
+	zoneChanged, err := client.ConfigDigest(t.Context())
+	require.NoError(t, err)
+	require.NotEqual(t, subnetChanged, zoneChanged)
+
+	// Deleting the last subnet leaves no subnets configuration to take the digest from.
+	set("vnets/vnet2/subnets", []map[string]any{})
+
+	subnetDeleted, err := client.ConfigDigest(t.Context())
+	require.NoError(t, err)
+	require.NotEqual(t, zoneChanged, subnetDeleted)
+
+	mu.Lock()
+	delete(sections, "vnets/vnet1/subnets")
+	mu.Unlock()
+
+	_, err = client.ConfigDigest(t.Context())
+	require.ErrorContains(t, err, "error reading SDN subnets configuration")
+}
@@ -0,0 +1,33 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+// SectionListResponseBody contains the body from a list response of any SDN configuration section.
+type SectionListResponseBody struct {
+	Data []*SectionEntry `json:"data,omitempty"`
+}
+
+// SectionEntry contains the attributes shared by the entries of all SDN configuration sections.
+type SectionEntry struct {
+	Digest string `json:"digest,omitempty"`
+}
+
+// ApplyResponseBody contains the body from a SDN apply response.
+type ApplyResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
+// NodeZoneStatusListResponseBody contains the body from a list response of the SDN zones of a node.
+type NodeZoneStatusListResponseBody struct {
+	Data []*NodeZoneStatus `json:"data,omitempty"`
+}
+
+// NodeZoneStatus is the status of a SDN zone on a node, e.g. "available" once the zone is applied.
+type NodeZoneStatus struct {
+	Name   string `json:"zone"`
+	Status string `json:"status"`
+}
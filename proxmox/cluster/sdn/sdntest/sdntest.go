@@ -0,0 +1,38 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package sdntest provides the fixtures shared by the tests of the SDN API clients and resources.
+package sdntest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// NewAPIClient creates an API client connected to a test server using the given handler.
+// The server is closed when the test completes.
+func NewAPIClient(t *testing.T, handler http.Handler) api.Client {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	creds, err := api.NewCredentials("", "", "", "root@pam!test=00000000-0000-0000-0000-000000000000", "", "")
+	require.NoError(t, err)
+
+	conn, err := api.NewConnection(server.URL, true, "")
+	require.NoError(t, err)
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	return client
+}
@@ -0,0 +1,35 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// Client is an interface for accessing the Proxmox SDN subnets management API.
+type Client struct {
+	api.Client
+}
+
+// ExpandPath expands a relative path to a full cluster SDN VNets API path.
+// Subnets are nested under their VNet, see subnetsPath and subnetPath.
+func (c *Client) ExpandPath(path string) string {
+	return fmt.Sprintf("cluster/sdn/vnets/%s", path)
+}
+
+// subnetsPath returns the full API path of the subnets of the given VNet.
+func (c *Client) subnetsPath(vnet string) string {
+	return c.ExpandPath(fmt.Sprintf("%s/subnets", url.PathEscape(vnet)))
+}
+
+// subnetPath returns the full API path of a single subnet of the given VNet.
+func (c *Client) subnetPath(vnet string, subnet string) string {
+	return fmt.Sprintf("%s/%s", c.subnetsPath(vnet), url.PathEscape(subnet))
+}
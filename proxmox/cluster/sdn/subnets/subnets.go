@@ -0,0 +1,149 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// List returns a list of SDN subnets of the given VNet, sorted by CIDR.
+func (c *Client) List(ctx context.Context, vnet string) ([]*SdnSubnetBody, error) {
+	resBody := &SdnSubnetListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.subnetsPath(vnet), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN subnets of VNet %s: %w", vnet, err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return compareCIDR(resBody.Data[i].CIDR, resBody.Data[j].CIDR) < 0
+	})
+
+	return resBody.Data, nil
+}
+
+// Get retrieves a single SDN subnet of the given VNet based on its identifier.
+func (c *Client) Get(ctx context.Context, vnet string, subnet string) (*SdnSubnetBody, error) {
+	resBody := &SdnSubnetGetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.subnetPath(vnet, subnet), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN subnet: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// Create creates a new SDN subnet in the given VNet.
+func (c *Client) Create(ctx context.Context, vnet string, data *SdnSubnetBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.subnetsPath(vnet), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN subnet: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing SDN subnet of the given VNet.
+func (c *Client) Update(ctx context.Context, vnet string, subnet string, data *SdnSubnetBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.subnetPath(vnet, subnet), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN subnet: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an SDN subnet from the given VNet.
+func (c *Client) Delete(ctx context.Context, vnet string, subnet string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.subnetPath(vnet, subnet), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN subnet: %w", err)
+	}
+
+	return nil
+}
+
+// FindSubnetsByGateway returns the SDN subnets of all VNets using the given gateway address, sorted by VNet
+// and then by CIDR. The addresses are compared parsed, so differently written IPv6 addresses still match.
+func (c *Client) FindSubnetsByGateway(ctx context.Context, gateway string) ([]*SdnSubnetBody, error) {
+	addr, err := netip.ParseAddr(gateway)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SDN subnet gateway %q: %w", gateway, err)
+	}
+
+	// The VNets are listed from the path the subnets are nested under.
+	vnetsBody := &SdnVNetNameListResponseBody{}
+
+	err = c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, vnetsBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN VNets: %w", err)
+	}
+
+	if vnetsBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(vnetsBody.Data, func(i, j int) bool {
+		return vnetsBody.Data[i].Name < vnetsBody.Data[j].Name
+	})
+
+	var found []*SdnSubnetBody
+
+	for _, vnet := range vnetsBody.Data {
+		list, err := c.List(ctx, vnet.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subnet := range list {
+			if subnet.Gateway == nil {
+				continue
+			}
+
+			if subnetGateway, err := netip.ParseAddr(*subnet.Gateway); err == nil && subnetGateway == addr {
+				subnet.VNet = &vnet.Name
+				found = append(found, subnet)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// compareCIDR orders CIDRs by network address and then by prefix length,
+// falling back to a plain string comparison for values that can't be parsed.
+func compareCIDR(a, b string) int {
+	pa, errA := netip.ParsePrefix(a)
+	pb, errB := netip.ParsePrefix(b)
+
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	if c := pa.Addr().Compare(pb.Addr()); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(pa.Bits(), pb.Bits())
+}
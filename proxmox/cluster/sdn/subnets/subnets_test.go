@@ -0,0 +1,62 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestFindSubnetsByGateway(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"/api2/json/cluster/sdn/vnets/": `{"data":[{"vnet":"vnet2"},{"vnet":"vnet1"}]}`,
+		"/api2/json/cluster/sdn/vnets/vnet1/subnets": `{"data":[
+			{"subnet":"zone1-10.0.0.0-24","cidr":"10.0.0.0/24","gateway":"10.0.0.1"},
+			{"subnet":"zone1-fd00::-64","cidr":"fd00::/64","gateway":"fd00:0:0:0::1"}
+		]}`,
+		"/api2/json/cluster/sdn/vnets/vnet2/subnets": `{"data":[
+			{"subnet":"zone1-10.0.1.0-24","cidr":"10.0.1.0/24","gateway":"10.0.1.1"},
+			{"subnet":"zone1-10.0.2.0-24","cidr":"10.0.2.0/24"}
+		]}`,
+	}
+
+	apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+
+	client := &Client{Client: apiClient}
+
+	found, err := client.FindSubnetsByGateway(t.Context(), "10.0.1.1")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "zone1-10.0.1.0-24", found[0].Subnet)
+	require.Equal(t, "vnet2", *found[0].VNet)
+
+	found, err = client.FindSubnetsByGateway(t.Context(), "fd00::1")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "fd00::/64", found[0].CIDR)
+
+	found, err = client.FindSubnetsByGateway(t.Context(), "10.0.2.1")
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	_, err = client.FindSubnetsByGateway(t.Context(), "10.0.1")
+	require.ErrorContains(t, err, "invalid SDN subnet gateway")
+}
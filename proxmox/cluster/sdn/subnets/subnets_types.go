@@ -0,0 +1,151 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// SdnSubnetListResponseBody contains the body from a SDN subnets list response.
+type SdnSubnetListResponseBody struct {
+	Data []*SdnSubnetBody `json:"data,omitempty"`
+}
+
+// SdnSubnetGetResponseBody contains the body from a SDN subnet get response.
+type SdnSubnetGetResponseBody struct {
+	Data *SdnSubnetBody `json:"data,omitempty"`
+}
+
+// SdnVNetNameListResponseBody contains the names of the VNets from a SDN VNets list response.
+type SdnVNetNameListResponseBody struct {
+	Data []*struct {
+		Name string `json:"vnet"`
+	} `json:"data,omitempty"`
+}
+
+// SdnSubnetBody represents the body of a SDN subnet in Proxmox.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/vnets/{vnet}/subnets
+type SdnSubnetBody struct {
+	// Subnet is the CIDR of the subnet in create requests, while responses carry the Proxmox
+	// identifier of the subnet in the "<zone>-<network>-<mask>" format instead.
+	Subnet string `json:"subnet" url:"subnet,omitempty"` // Should be omitted with update requests.
+	CIDR   string `json:"cidr,omitempty" url:"-"`
+
+	Type          *string             `json:"type,omitempty" url:"type,omitempty"`     // Should be omitted only with update requests.
+	Delete        *string             `json:"delete,omitempty" url:"delete,omitempty"` // Should be used only with update requests.
+	Digest        *string             `json:"digest,omitempty" url:"digest,omitempty"`
+	DHCPDNSServer *string             `json:"dhcp-dns-server,omitempty" url:"dhcp-dns-server,omitempty"`
+	DHCPRange     SdnSubnetDHCPRanges `json:"dhcp-range,omitempty" url:"dhcp-range,omitempty"`
+	DNSZonePrefix *string             `json:"dnszoneprefix,omitempty" url:"dnszoneprefix,omitempty"`
+	Gateway       *string             `json:"gateway,omitempty" url:"gateway,omitempty"`
+	Mask          *int32              `json:"mask,omitempty" url:"-"`
+	Network       *string             `json:"network,omitempty" url:"-"`
+	SNAT          *types.CustomBool   `json:"snat,omitempty" url:"snat,omitempty,int"`
+	VNet          *string             `json:"vnet,omitempty" url:"-"`
+	Zone          *string             `json:"zone,omitempty" url:"-"`
+}
+
+// ParseID parses the Proxmox identifier of a SDN subnet, in the "<zone>-<network>-<mask>" format, into the
+// zone and the CIDR of the subnet. Neither zone names nor networks contain hyphens, so the identifier is split
+// at its first and last hyphen.
+func ParseID(id string) (string, netip.Prefix, error) {
+	first := strings.Index(id, "-")
+	last := strings.LastIndex(id, "-")
+
+	if first <= 0 || last == first || last == len(id)-1 {
+		return "", netip.Prefix{}, fmt.Errorf("SDN subnet ID %q must be in the `<zone>-<network>-<mask>` format", id)
+	}
+
+	addr, err := netip.ParseAddr(id[first+1 : last])
+	if err != nil {
+		return "", netip.Prefix{}, fmt.Errorf("SDN subnet ID %q has an invalid network: %w", id, err)
+	}
+
+	bits, err := strconv.Atoi(id[last+1:])
+	if err != nil {
+		return "", netip.Prefix{}, fmt.Errorf("SDN subnet ID %q has an invalid mask: %w", id, err)
+	}
+
+	prefix, err := addr.Prefix(bits)
+	if err != nil || prefix.Addr() != addr {
+		return "", netip.Prefix{}, fmt.Errorf("SDN subnet ID %q has an invalid mask %d for network %s", id, bits, addr)
+	}
+
+	return id[:first], prefix, nil
+}
+
+// Prefix returns the CIDR of the subnet, taken from the CIDR of the response, or parsed from the identifier
+// of the subnet when the response carries none.
+func (b *SdnSubnetBody) Prefix() (netip.Prefix, error) {
+	if b.CIDR != "" {
+		prefix, err := netip.ParsePrefix(b.CIDR)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("SDN subnet %s has an invalid CIDR: %w", b.Subnet, err)
+		}
+
+		return prefix, nil
+	}
+
+	_, prefix, err := ParseID(b.Subnet)
+
+	return prefix, err
+}
+
+// SdnSubnetDHCPRange is a range of addresses of a SDN subnet leased by the DHCP server of the zone.
+type SdnSubnetDHCPRange struct {
+	StartAddress string `json:"start-address"`
+	EndAddress   string `json:"end-address"`
+}
+
+// SdnSubnetDHCPRanges is the list of DHCP ranges of a SDN subnet. DHCP is enabled for the subnet when it has
+// at least one range, provided that the zone of its VNet has DHCP enabled.
+type SdnSubnetDHCPRanges []SdnSubnetDHCPRange
+
+// EncodeValues encodes the DHCP ranges as repeated property strings in the
+// "start-address=<ip>,end-address=<ip>" format.
+func (r SdnSubnetDHCPRanges) EncodeValues(key string, v *url.Values) error {
+	for _, dhcpRange := range r {
+		v.Add(key, fmt.Sprintf("start-address=%s,end-address=%s", dhcpRange.StartAddress, dhcpRange.EndAddress))
+	}
+
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Depending on the Proxmox version, the ranges are returned
+// either as objects or as property strings in the "start-address=<ip>,end-address=<ip>" format.
+func (r *SdnSubnetDHCPRange) UnmarshalJSON(data []byte) error {
+	var propertyString string
+	if err := json.Unmarshal(data, &propertyString); err != nil {
+		type dhcpRange SdnSubnetDHCPRange
+
+		if err := json.Unmarshal(data, (*dhcpRange)(r)); err != nil {
+			return fmt.Errorf("error unmarshalling SDN subnet DHCP range: %w", err)
+		}
+
+		return nil
+	}
+
+	for _, property := range strings.Split(propertyString, ",") {
+		key, value, _ := strings.Cut(property, "=")
+
+		switch strings.TrimSpace(key) {
+		case "start-address":
+			r.StartAddress = strings.TrimSpace(value)
+		case "end-address":
+			r.EndAddress = strings.TrimSpace(value)
+		}
+	}
+
+	return nil
+}
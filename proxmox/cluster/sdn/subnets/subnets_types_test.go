@@ -0,0 +1,102 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package subnets
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSdnSubnetDHCPRangeUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	expected := SdnSubnetDHCPRanges{
+		{StartAddress: "10.0.0.100", EndAddress: "10.0.0.200"},
+		{StartAddress: "10.0.0.210", EndAddress: "10.0.0.220"},
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"objects", `{"dhcp-range":[` +
+			`{"start-address":"10.0.0.100","end-address":"10.0.0.200"},` +
+			`{"start-address":"10.0.0.210","end-address":"10.0.0.220"}]}`},
+		{"property strings", `{"dhcp-range":[` +
+			`"start-address=10.0.0.100,end-address=10.0.0.200",` +
+			`"end-address=10.0.0.220, start-address=10.0.0.210"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := &SdnSubnetBody{}
+			require.NoError(t, json.Unmarshal([]byte(tt.body), body))
+			require.Equal(t, expected, body.DHCPRange)
+		})
+	}
+}
+
+func TestSdnSubnetDHCPRangeEncode(t *testing.T) {
+	t.Parallel()
+
+	values, err := query.Values(&SdnSubnetBody{
+		DHCPRange: SdnSubnetDHCPRanges{
+			{StartAddress: "10.0.0.100", EndAddress: "10.0.0.200"},
+			{StartAddress: "10.0.0.210", EndAddress: "10.0.0.220"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"start-address=10.0.0.100,end-address=10.0.0.200",
+		"start-address=10.0.0.210,end-address=10.0.0.220",
+	}, values["dhcp-range"])
+
+	values, err = query.Values(&SdnSubnetBody{})
+	require.NoError(t, err)
+	require.NotContains(t, values, "dhcp-range")
+}
+
+func TestParseID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id     string
+		zone   string
+		prefix string
+		valid  bool
+	}{
+		{"zone1-10.0.0.0-24", "zone1", "10.0.0.0/24", true},
+		{"zone1-fd00::-64", "zone1", "fd00::/64", true},
+		{"zone1-10.0.0.1-24", "", "", false},
+		{"zone1-10.0.0.0-33", "", "", false},
+		{"zone1-10.0.0.0", "", "", false},
+		{"10.0.0.0-24", "", "", false},
+		{"zone1-10.0.0.0-", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			t.Parallel()
+
+			zone, prefix, err := ParseID(tt.id)
+			if !tt.valid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.zone, zone)
+			require.Equal(t, netip.MustParsePrefix(tt.prefix), prefix)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// Ranges of the VNet tags, VLAN IDs in VLAN and QinQ zones and VXLAN network identifiers (VNIs) in
+// VXLAN and EVPN zones.
+const (
+	maxVLANTag  = 4094
+	maxVXLANTag = 16777215
+)
+
+// VNetTagRange returns the range of valid tags of the VNets in a SDN zone of the given type.
+// ok is false if the VNets of the zone type take no tag.
+func VNetTagRange(zoneType string) (minTag, maxTag int32, ok bool) {
+	switch zoneType {
+	case "vlan", "qinq":
+		return 1, maxVLANTag, true
+	case "vxlan", "evpn":
+		return 1, maxVXLANTag, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// ValidateVNetTag checks that a tag is valid for the VNets of a SDN zone. The zone is looked up to
+// find out whether the tag is a VLAN ID or a VNI.
+func (c *Client) ValidateVNetTag(ctx context.Context, zone string, tag int32) error {
+	body, err := c.Zones().Get(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("error reading SDN zone %s: %w", zone, err)
+	}
+
+	if body.Type == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	zoneType := *body.Type
+
+	minTag, maxTag, ok := VNetTagRange(zoneType)
+	if !ok {
+		return fmt.Errorf("VNets of the %s SDN zone %s can't have a tag", zoneType, zone)
+	}
+
+	if tag < minTag || tag > maxTag {
+		kind := "VLAN ID"
+		if maxTag == maxVXLANTag {
+			kind = "VNI"
+		}
+
+		return fmt.Errorf("VNet tag %d is not a valid %s in the %s SDN zone %s, it must be between %d and %d",
+			tag, kind, zoneType, zone, minTag, maxTag)
+	}
+
+	return nil
+}
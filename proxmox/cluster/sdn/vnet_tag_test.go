@@ -0,0 +1,57 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestValidateVNetTag(t *testing.T) {
+	t.Parallel()
+
+	apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zone := strings.TrimPrefix(r.URL.Path, "/api2/json/cluster/sdn/zones/")
+		_, _ = fmt.Fprintf(w, `{"data":{"zone":%q,"type":%q}}`, zone, strings.TrimSuffix(zone, "1"))
+	}))
+
+	client := &Client{Client: apiClient}
+
+	tests := []struct {
+		zone string
+		tag  int32
+		err  string
+	}{
+		{"vlan1", 1, ""},
+		{"vlan1", 4094, ""},
+		{"vlan1", 0, "not a valid VLAN ID in the vlan SDN zone vlan1, it must be between 1 and 4094"},
+		{"qinq1", 4095, "not a valid VLAN ID"},
+		{"vxlan1", 100000, ""},
+		{"evpn1", 16777215, ""},
+		{"evpn1", 16777216, "not a valid VNI in the evpn SDN zone evpn1, it must be between 1 and 16777215"},
+		{"simple1", 10, "VNets of the simple SDN zone simple1 can't have a tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.zone, tt.tag), func(t *testing.T) {
+			t.Parallel()
+
+			err := client.ValidateVNetTag(t.Context(), tt.zone, tt.tag)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ipsPath returns the full API path of the IPAM entries of the given VNet.
+func (c *Client) ipsPath(vnet string) string {
+	return c.ExpandPath(fmt.Sprintf("%s/ips", url.PathEscape(vnet)))
+}
+
+// CreateIP creates a static IP-to-MAC mapping in the IPAM of the given VNet's zone.
+func (c *Client) CreateIP(ctx context.Context, vnet string, data *SdnVnetIPBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ipsPath(vnet), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN IPAM mapping: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIP updates a static IP-to-MAC mapping in the IPAM of the given VNet's zone.
+func (c *Client) UpdateIP(ctx context.Context, vnet string, data *SdnVnetIPBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ipsPath(vnet), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN IPAM mapping: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteIP removes a static IP-to-MAC mapping from the IPAM of the given VNet's zone.
+func (c *Client) DeleteIP(ctx context.Context, vnet string, data *SdnVnetIPBody) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ipsPath(vnet), data, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN IPAM mapping: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ListAll returns a list of all SDN VNets in the Proxmox cluster, regardless of their zone, sorted by name.
+func (c *Client) ListAll(ctx context.Context) ([]*SdnVnetBody, error) {
+	resBody := &SdnVnetListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN VNets: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].Name < resBody.Data[j].Name
+	})
+
+	return resBody.Data, nil
+}
+
+// ListByZone returns a list of SDN VNets belonging to the given zone, sorted by name.
+func (c *Client) ListByZone(ctx context.Context, zone string) ([]*SdnVnetBody, error) {
+	list, err := c.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*SdnVnetBody, 0, len(list))
+
+	for _, vnet := range list {
+		if vnet.Zone != nil && *vnet.Zone == zone {
+			result = append(result, vnet)
+		}
+	}
+
+	return result, nil
+}
+
+// Get retrieves a single SDN VNet based on its identifier.
+func (c *Client) Get(ctx context.Context, vnet string) (*SdnVnetBody, error) {
+	resBody := &SdnVnetGetResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(vnet)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN VNet: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// Update updates an existing SDN VNet.
+func (c *Client) Update(ctx context.Context, vnet string, data *SdnVnetBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(vnet)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN VNet: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnets
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	return &Client{Client: sdntest.NewAPIClient(t, handler)}
+}
+
+func TestListAllAndByZone(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api2/json/cluster/sdn/vnets/", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"data":[
+			{"vnet":"vnet3","zone":"zone1","type":"vnet"},
+			{"vnet":"vnet1","zone":"zone2","type":"vnet"},
+			{"vnet":"vnet2","zone":"zone1","type":"vnet","tag":100}
+		]}`))
+	})
+
+	names := func(list []*SdnVnetBody) []string {
+		result := make([]string, len(list))
+		for i, v := range list {
+			result[i] = v.Name
+		}
+
+		return result
+	}
+
+	all, err := client.ListAll(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []string{"vnet1", "vnet2", "vnet3"}, names(all))
+	require.Equal(t, int32(100), *all[1].Tag)
+
+	byZone, err := client.ListByZone(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"vnet2", "vnet3"}, names(byZone))
+
+	byZone, err = client.ListByZone(t.Context(), "zone3")
+	require.NoError(t, err)
+	require.Empty(t, byZone)
+}
+
+func TestIsolatePortsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var form url.Values
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api2/json/cluster/sdn/vnets/vnet1", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"data":{"vnet":"vnet1","zone":"zone1","type":"vnet","isolate-ports":1}}`))
+		case http.MethodPut:
+			require.NoError(t, r.ParseForm())
+			form = r.PostForm
+
+			_, _ = w.Write([]byte(`{"data":null}`))
+		}
+	})
+
+	vnet, err := client.Get(t.Context(), "vnet1")
+	require.NoError(t, err)
+	require.NotNil(t, vnet.IsolatePorts)
+	require.True(t, bool(*vnet.IsolatePorts))
+
+	err = client.Update(t.Context(), "vnet1", &SdnVnetBody{IsolatePorts: ptr.Ptr(types.CustomBool(false))})
+	require.NoError(t, err)
+	require.Equal(t, "0", form.Get("isolate-ports"))
+
+	err = client.Update(t.Context(), "vnet1", &SdnVnetBody{Delete: ptr.Ptr("isolate-ports")})
+	require.NoError(t, err)
+	require.False(t, form.Has("isolate-ports"))
+	require.Equal(t, "isolate-ports", form.Get("delete"))
+}
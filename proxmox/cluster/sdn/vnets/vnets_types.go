@@ -0,0 +1,45 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vnets
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// SdnVnetListResponseBody contains the body from a SDN VNets list response.
+type SdnVnetListResponseBody struct {
+	Data []*SdnVnetBody `json:"data,omitempty"`
+}
+
+// SdnVnetGetResponseBody contains the body from a SDN VNet get response.
+type SdnVnetGetResponseBody struct {
+	Data *SdnVnetBody `json:"data,omitempty"`
+}
+
+// SdnVnetBody represents the body of a SDN VNet in Proxmox.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/vnets
+type SdnVnetBody struct {
+	Name string `json:"vnet" url:"vnet"`
+
+	Type         *string           `json:"type,omitempty" url:"type,omitempty"`     // Should be omitted only with update requests.
+	Delete       *string           `json:"delete,omitempty" url:"delete,omitempty"` // Should be used only with update requests.
+	Alias        *string           `json:"alias,omitempty" url:"alias,omitempty"`
+	Digest       *string           `json:"digest,omitempty" url:"digest,omitempty"`
+	IsolatePorts *types.CustomBool `json:"isolate-ports,omitempty" url:"isolate-ports,omitempty,int"`
+	Tag          *int32            `json:"tag,omitempty" url:"tag,omitempty"`
+	VlanAware    *types.CustomBool `json:"vlanaware,omitempty" url:"vlanaware,omitempty,int"`
+	Zone         *string           `json:"zone,omitempty" url:"zone,omitempty"`
+}
+
+// SdnVnetIPBody represents the body of a SDN VNet IPAM mapping request.
+// Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/vnets/{vnet}/ips
+type SdnVnetIPBody struct {
+	Zone string  `json:"zone" url:"zone"`
+	IP   string  `json:"ip" url:"ip"`
+	MAC  *string `json:"mac,omitempty" url:"mac,omitempty"`
+	VMID *int64  `json:"vmid,omitempty" url:"vmid,omitempty"`
+}
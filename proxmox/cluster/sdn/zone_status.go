@@ -0,0 +1,82 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ZoneStatusAvailable is the status of a SDN zone that is applied and active on a node.
+const ZoneStatusAvailable = "available"
+
+// zoneStatusPollDelay is the delay between two probes of the status of a SDN zone.
+const zoneStatusPollDelay = time.Second
+
+// NodeZoneStatus returns the status of the SDN zone on the node, or an empty string if the node doesn't
+// have the zone, e.g. because the zone isn't applied yet or doesn't span the node.
+func (c *Client) NodeZoneStatus(ctx context.Context, node string, zone string) (string, error) {
+	resBody := &NodeZoneStatusListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, fmt.Sprintf("nodes/%s/sdn/zones", url.PathEscape(node)), nil, resBody)
+	if err != nil {
+		return "", fmt.Errorf("error reading SDN zones status of node %s: %w", node, err)
+	}
+
+	if resBody.Data == nil {
+		return "", api.ErrNoDataObjectInResponse
+	}
+
+	for _, status := range resBody.Data {
+		if status.Name == zone {
+			return status.Status, nil
+		}
+	}
+
+	return "", nil
+}
+
+// VerifyZone waits until the SDN zone is available on all the nodes, probing its status on every node
+// until the timeout expires. The error lists the nodes on which the zone isn't available, with its status.
+func (c *Client) VerifyZone(ctx context.Context, zone string, nodes []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		var unavailable []string
+
+		for _, node := range nodes {
+			status, err := c.NodeZoneStatus(ctx, node, zone)
+
+			switch {
+			case err != nil && ctx.Err() == nil:
+				return fmt.Errorf("error verifying SDN zone %s: %w", zone, err)
+			case status == "":
+				unavailable = append(unavailable, node+" (missing)")
+			case status != ZoneStatusAvailable:
+				unavailable = append(unavailable, fmt.Sprintf("%s (%s)", node, status))
+			}
+		}
+
+		if len(unavailable) == 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(zoneStatusPollDelay):
+		case <-ctx.Done():
+			return fmt.Errorf("SDN zone %s is not available on nodes %s after %s",
+				zone, strings.Join(unavailable, ", "), timeout)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+func TestVerifyZone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// pve2 reports the given statuses of the zone in turn, the last one being repeated.
+		pve2 []string
+		err  string
+	}{
+		{"available", []string{"available"}, ""},
+		{"applied while probing", []string{"", "pending", "available"}, ""},
+		{"pending", []string{"pending"}, "not available on nodes pve2 (pending)"},
+		{"missing", []string{""}, "not available on nodes pve2 (missing)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var probes atomic.Int32
+
+			apiClient := sdntest.NewAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := "available"
+
+				if r.URL.Path == "/api2/json/nodes/pve2/sdn/zones" {
+					status = tt.pve2[min(int(probes.Add(1))-1, len(tt.pve2)-1)]
+				}
+
+				if status == "" {
+					_, _ = w.Write([]byte(`{"data":[{"zone":"other","status":"available"}]}`))
+					return
+				}
+
+				_, _ = fmt.Fprintf(w, `{"data":[{"zone":"other","status":"error"},{"zone":"zone1","status":%q}]}`, status)
+			}))
+
+			client := &Client{Client: apiClient}
+
+			// The failing cases give up quickly, the others have the time to probe all the statuses.
+			timeout := 5 * time.Second
+			if tt.err != "" {
+				timeout = 100 * time.Millisecond
+			}
+
+			err := client.VerifyZone(t.Context(), "zone1", []string{"pve1", "pve2"}, timeout)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int32(len(tt.pve2)), probes.Load())
+		})
+	}
+}
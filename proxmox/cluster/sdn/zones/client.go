@@ -15,6 +15,38 @@ import (
 // Client is an interface for accessing the Proxmox SDN zones management API.
 type Client struct {
 	api.Client
+
+	serializeWrites bool
+}
+
+// ClientOption is an option for the SDN zones client.
+type ClientOption interface {
+	apply(c *Client)
+}
+
+type withSerializedWrites struct{}
+
+// WithSerializedWrites is an option to serialize the zone write requests of all the SDN zones clients
+// created with this option within the process. Proxmox guards the whole SDN configuration with a single
+// lock, so concurrent writes, even to different zones, contend for it and may time out. Only the zone
+// writes are serialized: the writes of the VNets, subnets, controllers and IPAM mappings don't wait for
+// them and still rely on the retries on a locked configuration.
+func WithSerializedWrites() ClientOption {
+	return withSerializedWrites{}
+}
+
+func (w withSerializedWrites) apply(c *Client) {
+	c.serializeWrites = true
+}
+
+// NewClient creates a new SDN zones client.
+func NewClient(client api.Client, opts ...ClientOption) *Client {
+	c := &Client{Client: client}
+	for _, opt := range opts {
+		opt.apply(c)
+	}
+
+	return c
 }
 
 // ExpandPath expands a relative path to a full cluster SDN zones API path.
@@ -0,0 +1,173 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// diffIgnoredFields are the fields of the zone views that describe the views themselves, not the zone.
+var diffIgnoredFields = map[string]struct{}{
+	"digest":  {},
+	"pending": {},
+	"state":   {},
+}
+
+// PendingStateDeleted is the pending state of a SDN zone that is deleted but whose deletion is not yet applied.
+const PendingStateDeleted = "deleted"
+
+// PendingState returns the pending state of a SDN zone reported by Proxmox, e.g. "new", "changed" or
+// PendingStateDeleted, or an empty string if the zone has no staged changes. Unlike Get, it also finds
+// zones whose deletion is staged but not yet applied.
+func (c *Client) PendingState(ctx context.Context, zone string) (string, error) {
+	pending, err := c.getView(ctx, zone, &SdnZoneGetRequestBody{Pending: types.CustomBool(true).Pointer()})
+	if err != nil {
+		return "", fmt.Errorf("error reading pending SDN zone: %w", err)
+	}
+
+	state, _ := pending["state"].(string)
+
+	return state, nil
+}
+
+// GetPending retrieves the configuration of a SDN zone including its staged changes, i.e. the configuration the
+// zone has once the SDN configuration is applied. A zone whose deletion is staged is reported as not existing.
+func (c *Client) GetPending(ctx context.Context, zone string) (*SdnZoneBody, error) {
+	view, err := c.getView(ctx, zone, &SdnZoneGetRequestBody{Pending: types.CustomBool(true).Pointer()})
+	if err != nil {
+		return nil, fmt.Errorf("error reading pending SDN zone: %w", err)
+	}
+
+	if state, _ := view["state"].(string); state == PendingStateDeleted {
+		return nil, fmt.Errorf("error reading pending SDN zone %s: %w", zone, api.ErrResourceDoesNotExist)
+	}
+
+	staged := stagedValues(view)
+	delete(staged, "state")
+
+	data, err := json.Marshal(staged)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding pending SDN zone: %w", err)
+	}
+
+	body := &SdnZoneBody{}
+
+	err = json.Unmarshal(data, body)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding pending SDN zone: %w", err)
+	}
+
+	return body, nil
+}
+
+// Diff returns the changes of a SDN zone that are staged but not yet applied.
+// The zone doesn't have to be applied yet, in which case all its fields are reported as changed.
+func (c *Client) Diff(ctx context.Context, zone string) (*SdnZoneDiff, error) {
+	running, err := c.getView(ctx, zone, &SdnZoneGetRequestBody{Running: types.CustomBool(true).Pointer()})
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		return nil, fmt.Errorf("error reading running SDN zone: %w", err)
+	}
+
+	pending, err := c.getView(ctx, zone, &SdnZoneGetRequestBody{Pending: types.CustomBool(true).Pointer()})
+	if err != nil {
+		return nil, fmt.Errorf("error reading pending SDN zone: %w", err)
+	}
+
+	return diffViews(running, pending), nil
+}
+
+func (c *Client) getView(ctx context.Context, zone string, reqBody *SdnZoneGetRequestBody) (map[string]any, error) {
+	resBody := &SdnZoneViewResponseBody{}
+
+	err := c.doRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(zone)), reqBody, resBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// stagedValues returns the values of a zone once its staged changes are applied, from its pending view.
+// The pending view carries the running values at the top level and the staged values in its "pending" object,
+// where a null value is a field that is removed.
+func stagedValues(pending map[string]any) map[string]any {
+	staged := map[string]any{}
+
+	for field, value := range pending {
+		staged[field] = value
+	}
+
+	delete(staged, "pending")
+
+	if values, ok := pending["pending"].(map[string]any); ok {
+		for field, value := range values {
+			if value == nil {
+				delete(staged, field)
+			} else {
+				staged[field] = value
+			}
+		}
+	}
+
+	return staged
+}
+
+// diffViews compares the running view of a zone with its pending view. The pending view carries the
+// running values at the top level and the staged values in its "pending" object.
+func diffViews(running map[string]any, pending map[string]any) *SdnZoneDiff {
+	diff := &SdnZoneDiff{}
+
+	if state, ok := pending["state"].(string); ok {
+		diff.State = &state
+	}
+
+	staged := stagedValues(pending)
+
+	fields := map[string]struct{}{}
+
+	for field := range running {
+		fields[field] = struct{}{}
+	}
+
+	for field := range staged {
+		fields[field] = struct{}{}
+	}
+
+	for field := range fields {
+		if _, ignored := diffIgnoredFields[field]; ignored {
+			continue
+		}
+
+		if !reflect.DeepEqual(running[field], staged[field]) {
+			diff.Changes = append(diff.Changes, SdnZoneFieldChange{
+				Field:   field,
+				Running: running[field],
+				Pending: staged[field],
+			})
+		}
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Field < diff.Changes[j].Field
+	})
+
+	return diff
+}
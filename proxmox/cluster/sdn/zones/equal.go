@@ -0,0 +1,78 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+// Equal reports whether two zone bodies describe the same zone configuration. Unset strings are equal to
+// empty ones and unset flags to disabled ones, as Proxmox omits both, and the comma-separated lists of nodes,
+// peers and exit nodes are compared as sets. The digest and the delete list describe the requests, not the
+// zone, and are ignored. The defaults Proxmox applies to other omitted fields, e.g. the VXLAN port, are not.
+func (b *SdnZoneBody) Equal(other *SdnZoneBody) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+
+	return b.Name == other.Name &&
+		equalString(b.Type, other.Type) &&
+		equalFlag(b.AdvertiseSubnets, other.AdvertiseSubnets) &&
+		equalString(b.Bridge, other.Bridge) &&
+		equalFlag(b.BridgeDisableMacLearning, other.BridgeDisableMacLearning) &&
+		equalString(b.Controller, other.Controller) &&
+		equalString(b.Dhcp, other.Dhcp) &&
+		equalFlag(b.DisableArpNdSuppression, other.DisableArpNdSuppression) &&
+		equalString(b.Dns, other.Dns) &&
+		equalString(b.Dnszone, other.Dnszone) &&
+		ptr.Eq(b.DpID, other.DpID) &&
+		equalList(b.Exitnodes, other.Exitnodes) &&
+		equalFlag(b.ExitnodesLocalRouting, other.ExitnodesLocalRouting) &&
+		equalString(b.ExitnodesPrimary, other.ExitnodesPrimary) &&
+		equalString(b.Ipam, other.Ipam) &&
+		equalString(b.Mac, other.Mac) &&
+		ptr.Eq(b.Mtu, other.Mtu) &&
+		equalList(b.Nodes, other.Nodes) &&
+		equalList(b.Peers, other.Peers) &&
+		equalString(b.Reversedns, other.Reversedns) &&
+		equalString(b.RtImport, other.RtImport) &&
+		ptr.Eq(b.Tag, other.Tag) &&
+		equalString(b.VlanProtocol, other.VlanProtocol) &&
+		ptr.Eq(b.VrfVxlan, other.VrfVxlan) &&
+		ptr.Eq(b.VxlanPort, other.VxlanPort)
+}
+
+func equalString(a *string, b *string) bool {
+	return ptr.Or(a, "") == ptr.Or(b, "")
+}
+
+func equalFlag(a *bool, b *bool) bool {
+	return ptr.Or(a, false) == ptr.Or(b, false)
+}
+
+// equalList compares two comma-separated lists as sets, ignoring the whitespace around their elements.
+func equalList(a *string, b *string) bool {
+	return slices.Equal(listElements(a), listElements(b))
+}
+
+// listElements returns the sorted, deduplicated elements of a comma-separated list.
+func listElements(list *string) []string {
+	var elements []string
+
+	for _, element := range strings.Split(ptr.Or(list, ""), ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+
+	slices.Sort(elements)
+
+	return slices.Compact(elements)
+}
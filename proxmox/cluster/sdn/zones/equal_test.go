@@ -0,0 +1,99 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		a     *SdnZoneBody
+		b     *SdnZoneBody
+		equal bool
+	}{
+		{"both nil", nil, nil, true},
+		{"one nil", &SdnZoneBody{Name: "zone1"}, nil, false},
+		{"different names", &SdnZoneBody{Name: "zone1"}, &SdnZoneBody{Name: "zone2"}, false},
+		{"unset and empty string", &SdnZoneBody{Name: "zone1"}, &SdnZoneBody{Name: "zone1", Dns: ptr.Ptr("")}, true},
+		{"unset and disabled flag", &SdnZoneBody{Name: "zone1"}, &SdnZoneBody{Name: "zone1", AdvertiseSubnets: ptr.Ptr(false)}, true},
+		{"unset and zero number", &SdnZoneBody{Name: "zone1"}, &SdnZoneBody{Name: "zone1", Mtu: ptr.Ptr(int32(0))}, false},
+		{
+			"nodes in another order",
+			&SdnZoneBody{Name: "zone1", Nodes: ptr.Ptr("pve1,pve2")},
+			&SdnZoneBody{Name: "zone1", Nodes: ptr.Ptr("pve2, pve1")},
+			true,
+		},
+		{
+			"different nodes",
+			&SdnZoneBody{Name: "zone1", Nodes: ptr.Ptr("pve1,pve2")},
+			&SdnZoneBody{Name: "zone1", Nodes: ptr.Ptr("pve1")},
+			false,
+		},
+		{"unset and empty list", &SdnZoneBody{Name: "zone1"}, &SdnZoneBody{Name: "zone1", Peers: ptr.Ptr("")}, true},
+		{
+			"different digests",
+			&SdnZoneBody{Name: "zone1", Digest: ptr.Ptr("1")},
+			&SdnZoneBody{Name: "zone1", Digest: ptr.Ptr("2"), Delete: ptr.Ptr("mtu")},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.equal, tt.a.Equal(tt.b))
+			require.Equal(t, tt.equal, tt.b.Equal(tt.a))
+		})
+	}
+}
+
+func TestEqualComparesAllFields(t *testing.T) {
+	t.Parallel()
+
+	ignored := map[string]bool{"Digest": true, "Delete": true}
+
+	fields := reflect.TypeFor[SdnZoneBody]()
+
+	for i := range fields.NumField() {
+		field := fields.Field(i)
+		if ignored[field.Name] {
+			continue
+		}
+
+		t.Run(field.Name, func(t *testing.T) {
+			t.Parallel()
+
+			changed := &SdnZoneBody{}
+			value := reflect.ValueOf(changed).Elem().Field(i)
+
+			switch field.Type {
+			case reflect.TypeFor[string]():
+				value.SetString("zone1")
+			case reflect.TypeFor[*string]():
+				value.Set(reflect.ValueOf(ptr.Ptr("value")))
+			case reflect.TypeFor[*bool]():
+				value.Set(reflect.ValueOf(ptr.Ptr(true)))
+			case reflect.TypeFor[*int32]():
+				value.Set(reflect.ValueOf(ptr.Ptr(int32(1))))
+			default:
+				t.Fatalf("unexpected type %s of field %s", field.Type, field.Name)
+			}
+
+			require.False(t, (&SdnZoneBody{}).Equal(changed), "a change of %s must be detected", field.Name)
+		})
+	}
+}
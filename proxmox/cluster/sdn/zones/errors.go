@@ -0,0 +1,54 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// ErrSDNNotAvailable is returned when SDN is not installed or not enabled on the Proxmox cluster.
+const ErrSDNNotAvailable api.Error = "SDN is not installed or not enabled on the Proxmox cluster"
+
+// sdnConfigDir is the directory of the SDN configuration, which doesn't exist when SDN isn't installed.
+const sdnConfigDir = "/etc/pve/sdn"
+
+// checkAvailability marks the errors caused by SDN not being available with ErrSDNNotAvailable: the SDN API
+// not being implemented, or the SDN configuration directory not existing. Other errors about missing files,
+// e.g. of a node, are not matched.
+func checkAvailability(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) && httpErr.Code == http.StatusNotImplemented {
+		return fmt.Errorf("%w: %w", ErrSDNNotAvailable, err)
+	}
+
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "sdn not configured") ||
+		(strings.Contains(msg, sdnConfigDir) && strings.Contains(msg, "no such file")) {
+		return fmt.Errorf("%w: %w", ErrSDNNotAvailable, err)
+	}
+
+	return err
+}
+
+// doRequest performs a request, reporting the errors caused by SDN not being available with ErrSDNNotAvailable.
+func (c *Client) doRequest(
+	ctx context.Context,
+	method, path string,
+	requestBody, responseBody interface{},
+) error {
+	return checkAvailability(c.DoRequest(ctx, method, path, requestBody, responseBody))
+}
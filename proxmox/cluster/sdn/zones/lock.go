@@ -0,0 +1,88 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const lockRetryAttempts = 5
+
+// lockRetryDelay is the initial delay between retries of a request rejected due to the SDN lock.
+// It is a variable so tests can shorten it.
+var lockRetryDelay = 1 * time.Second
+
+// writeSlot is held by the zones clients created with WithSerializedWrites while they perform a zone write
+// request. It only serializes the zone writes, not the writes of the other SDN objects. It is a channel
+// rather than a mutex, so that waiting for it can be canceled with the request context.
+var writeSlot = make(chan struct{}, 1)
+
+// lockErrorMessages lists fragments of the errors returned by Proxmox when the SDN configuration
+// could not be locked because another operation holds the lock.
+var lockErrorMessages = []string{
+	"got lock request timeout",
+	"can't lock file",
+}
+
+// isLockError returns true if the error is caused by a contention on the SDN configuration lock.
+func isLockError(err error) bool {
+	if errors.Is(err, ErrSDNNotAvailable) {
+		return false
+	}
+
+	for _, msg := range lockErrorMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// doWithLockRetry performs a request, retrying it with backoff while the SDN configuration is locked.
+// With serialized writes, the request waits for the other in-process writes to complete first.
+func (c *Client) doWithLockRetry(
+	ctx context.Context,
+	method, path string,
+	requestBody, responseBody interface{},
+) error {
+	if c.serializeWrites {
+		select {
+		case writeSlot <- struct{}{}:
+			defer func() { <-writeSlot }()
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting for other SDN writes to complete: %w", ctx.Err())
+		}
+	}
+
+	return retry.Do(
+		func() error {
+			return c.doRequest(ctx, method, path, requestBody, responseBody)
+		},
+		retry.Context(ctx),
+		retry.Attempts(lockRetryAttempts),
+		retry.Delay(lockRetryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			tflog.Warn(ctx, "retrying SDN zone request on locked configuration", map[string]interface{}{
+				"method":  method,
+				"path":    path,
+				"attempt": n,
+				"error":   err.Error(),
+			})
+		}),
+		retry.RetryIf(isLockError),
+	)
+}
@@ -0,0 +1,257 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn/sdntest"
+)
+
+const mockZonesPath = "/api2/json/cluster/sdn/zones"
+
+// writeStatus writes a raw response with a custom reason phrase, the way Proxmox reports errors.
+func writeStatus(t *testing.T, w http.ResponseWriter, code int, reason string) {
+	t.Helper()
+
+	writeStatusWithBody(t, w, code, reason, "")
+}
+
+// writeStatusWithBody writes a raw response with a custom reason phrase and a JSON body.
+func writeStatusWithBody(t *testing.T, w http.ResponseWriter, code int, reason string, body string) {
+	t.Helper()
+
+	conn, buf, err := w.(http.Hijacker).Hijack()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(
+		buf,
+		"HTTP/1.1 %d %s\r\nContent-Type: application/json\r\nContent-Length: %d\r\nConnection: close\r\n\r\n%s",
+		code, reason, len(body), body,
+	)
+	require.NoError(t, err)
+	require.NoError(t, buf.Flush())
+}
+
+// newTestClient creates a zones client connected to a test server using the given handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	return &Client{Client: sdntest.NewAPIClient(t, handler)}
+}
+
+// mockResponse is a canned error response of the mock server.
+type mockResponse struct {
+	code   int
+	reason string
+	body   string
+}
+
+// mockServer is an in-memory Proxmox SDN zones API. Zones are stored as raw objects, the way
+// they're returned by the API, and listed in the order they were created. Errors can be queued
+// to be returned by the next requests instead of handling them. Every change of the zones changes
+// the digest of the configuration, and updates carrying an outdated digest are rejected.
+type mockServer struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	zones    []map[string]any
+	failures []mockResponse
+	requests []string
+	version  int
+
+	// editAfterGet simulates a change by another client right after the next read of a zone.
+	editAfterGet func(zone map[string]any)
+}
+
+// newMockServer creates a mock server holding the given zones and a client connected to it.
+func newMockServer(t *testing.T, zones ...map[string]any) (*mockServer, *Client) {
+	t.Helper()
+
+	s := &mockServer{t: t, zones: zones}
+
+	return s, newTestClient(t, s.handle)
+}
+
+// failNext queues an error response with a custom reason phrase for the next request.
+func (s *mockServer) failNext(code int, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, mockResponse{code: code, reason: reason})
+}
+
+// failNextNotFound queues the error returned by Proxmox for a missing zone.
+func (s *mockServer) failNextNotFound(zone string) {
+	s.failNext(http.StatusInternalServerError, fmt.Sprintf("sdn zone object ID '%s' does not exist", zone))
+}
+
+// failNextLocked queues the error returned by Proxmox when the SDN configuration is locked.
+func (s *mockServer) failNextLocked() {
+	s.failNext(http.StatusInternalServerError, "cfs-lock 'file-sdn_zones_cfg' error: got lock request timeout")
+}
+
+// failNextValidation queues the error returned by Proxmox when parameters fail the verification.
+func (s *mockServer) failNextValidation(errors map[string]string) {
+	body, err := json.Marshal(map[string]any{"data": nil, "errors": errors})
+	require.NoError(s.t, err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, mockResponse{
+		code:   http.StatusBadRequest,
+		reason: "Parameter verification failed.",
+		body:   string(body),
+	})
+}
+
+// editAfterNextGet changes a zone right after it's read by the next request, as if another client did.
+func (s *mockServer) editAfterNextGet(edit func(zone map[string]any)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.editAfterGet = edit
+}
+
+// digest returns the digest of the current configuration.
+func (s *mockServer) digest() string {
+	return strconv.Itoa(s.version)
+}
+
+// calls returns the handled requests in the "<method> <zone>" format, with an empty zone for the collection.
+func (s *mockServer) calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// zone returns a stored zone, or nil if it doesn't exist.
+func (s *mockServer) zone(name string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.indexOf(name); i >= 0 {
+		return s.zones[i]
+	}
+
+	return nil
+}
+
+func (s *mockServer) indexOf(name string) int {
+	return slices.IndexFunc(s.zones, func(z map[string]any) bool {
+		return z["zone"] == name
+	})
+}
+
+func (s *mockServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name, ok := strings.CutPrefix(r.URL.Path, mockZonesPath)
+	if !ok {
+		writeStatus(s.t, w, http.StatusNotImplemented, fmt.Sprintf("Method '%s %s' not implemented", r.Method, r.URL.Path))
+		return
+	}
+
+	name = strings.TrimPrefix(name, "/")
+	s.requests = append(s.requests, strings.TrimSpace(r.Method+" "+name))
+
+	if len(s.failures) > 0 {
+		failure := s.failures[0]
+		s.failures = s.failures[1:]
+
+		writeStatusWithBody(s.t, w, failure.code, failure.reason, failure.body)
+
+		return
+	}
+
+	require.NoError(s.t, r.ParseForm())
+
+	if digest := r.PostForm.Get("digest"); r.Method == http.MethodPut && digest != "" && digest != s.digest() {
+		writeStatus(s.t, w, http.StatusInternalServerError,
+			"detected modified configuration - file changed by other user? Try again.")
+		return
+	}
+
+	i := s.indexOf(name)
+	if name != "" && i < 0 {
+		writeStatus(s.t, w, http.StatusInternalServerError, fmt.Sprintf("sdn zone object ID '%s' does not exist", name))
+		return
+	}
+
+	var data any
+
+	switch {
+	case r.Method == http.MethodGet && name == "":
+		data = s.zones
+	case r.Method == http.MethodGet:
+		zone := maps.Clone(s.zones[i])
+		zone["digest"] = s.digest()
+		data = zone
+
+		if s.editAfterGet != nil {
+			s.editAfterGet(s.zones[i])
+			s.editAfterGet = nil
+			s.version++
+		}
+	case r.Method == http.MethodPost:
+		zone := map[string]any{}
+		applyForm(zone, r.PostForm)
+
+		if s.indexOf(zone["zone"].(string)) >= 0 {
+			writeStatus(s.t, w, http.StatusInternalServerError, fmt.Sprintf("sdn zone object ID '%s' already defined", zone["zone"]))
+			return
+		}
+
+		s.zones = append(s.zones, zone)
+	case r.Method == http.MethodPut:
+		applyForm(s.zones[i], r.PostForm)
+	case r.Method == http.MethodDelete:
+		s.zones = slices.Delete(s.zones, i, i+1)
+	}
+
+	if r.Method != http.MethodGet {
+		s.version++
+	}
+
+	require.NoError(s.t, json.NewEncoder(w).Encode(map[string]any{"data": data}))
+}
+
+// applyForm applies the values of a create or update request to a stored zone.
+func applyForm(zone map[string]any, form map[string][]string) {
+	for key, values := range form {
+		value := values[0]
+
+		switch key {
+		case "delete":
+			for _, field := range strings.Split(value, ",") {
+				delete(zone, field)
+			}
+		case "digest":
+		default:
+			if number, err := strconv.Atoi(value); err == nil {
+				zone[key] = number
+			} else {
+				zone[key] = value
+			}
+		}
+	}
+}
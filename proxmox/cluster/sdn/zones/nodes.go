@@ -0,0 +1,132 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+// digestErrorMessage is a fragment of the error returned by Proxmox when an update carries the digest
+// of an outdated SDN configuration, i.e. the configuration was modified since it was read.
+const digestErrorMessage = "detected modified configuration"
+
+// membershipSlot is held while the nodes of a zone are read, modified and written back, so that the
+// concurrent membership changes of the process don't invalidate the digest of each other.
+var membershipSlot = make(chan struct{}, 1)
+
+// isDigestError returns true if the error is caused by an update based on an outdated configuration.
+func isDigestError(err error) bool {
+	return strings.Contains(err.Error(), digestErrorMessage)
+}
+
+// AddNodes adds the nodes to the SDN zone, keeping the nodes it already has. A zone without nodes spans
+// the whole cluster, so it already includes the nodes and is left unchanged.
+func (c *Client) AddNodes(ctx context.Context, zone string, nodes []string) error {
+	return c.updateNodes(ctx, zone, func(current []string) ([]string, error) {
+		if len(current) == 0 {
+			return current, nil
+		}
+
+		for _, node := range nodes {
+			if !slices.Contains(current, node) {
+				current = append(current, node)
+			}
+		}
+
+		return current, nil
+	})
+}
+
+// RemoveNodes removes the nodes from the SDN zone, keeping its other nodes. A zone without nodes spans
+// the whole cluster, so removing all the nodes of the zone is rejected.
+func (c *Client) RemoveNodes(ctx context.Context, zone string, nodes []string) error {
+	return c.updateNodes(ctx, zone, func(current []string) ([]string, error) {
+		if len(current) == 0 {
+			return nil, fmt.Errorf("SDN zone %s spans the whole cluster, it has no nodes to remove", zone)
+		}
+
+		remaining := slices.DeleteFunc(current, func(node string) bool {
+			return slices.Contains(nodes, node)
+		})
+
+		if len(remaining) == 0 {
+			return nil, fmt.Errorf("removing all nodes of SDN zone %s would make it span the whole cluster", zone)
+		}
+
+		return remaining, nil
+	})
+}
+
+// updateNodes reads the nodes of the zone, applies the change and writes them back along with the digest
+// of the configuration they were read from. When the configuration was modified by another client in the
+// meantime, Proxmox rejects the update and the change is applied again to the new nodes.
+func (c *Client) updateNodes(ctx context.Context, zone string, change func(current []string) ([]string, error)) error {
+	select {
+	case membershipSlot <- struct{}{}:
+		defer func() { <-membershipSlot }()
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for other SDN zone node changes to complete: %w", ctx.Err())
+	}
+
+	err := retry.Do(
+		func() error {
+			data, err := c.Get(ctx, zone)
+			if err != nil {
+				return err
+			}
+
+			var current []string
+
+			for _, node := range strings.Split(ptr.Or(data.Nodes, ""), ",") {
+				if node = strings.TrimSpace(node); node != "" {
+					current = append(current, node)
+				}
+			}
+
+			updated, err := change(slices.Clone(current))
+			if err != nil {
+				return err
+			}
+
+			if slices.Equal(current, updated) {
+				return nil
+			}
+
+			return c.Update(ctx, zone, &SdnZoneBody{
+				Name:   zone,
+				Nodes:  ptr.Ptr(strings.Join(updated, ",")),
+				Digest: data.Digest,
+			})
+		},
+		retry.Context(ctx),
+		retry.Attempts(lockRetryAttempts),
+		retry.Delay(lockRetryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			tflog.Warn(ctx, "retrying SDN zone nodes update on modified configuration", map[string]interface{}{
+				"zone":    zone,
+				"attempt": n,
+				"error":   err.Error(),
+			})
+		}),
+		retry.RetryIf(isDigestError),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating nodes of SDN zone %s: %w", zone, err)
+	}
+
+	return nil
+}
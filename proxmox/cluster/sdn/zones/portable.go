@@ -0,0 +1,96 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// zoneConfigVersion is the version of the portable SDN zone configuration format.
+const zoneConfigVersion = 1
+
+// supportedZoneTypes lists the SDN zone types that can be imported from a portable configuration.
+var supportedZoneTypes = []string{"simple", "vlan", "qinq", "vxlan", "evpn"}
+
+// ExportConfig returns the configuration of a SDN zone as a portable JSON document, which can be
+// imported with ImportConfig on another cluster. The attributes referring to the nodes or to other
+// SDN objects of the cluster, i.e. the nodes, exit nodes and VXLAN peers, the controller, the IPAM and
+// the DNS servers, are left out, as they don't exist on the other cluster: they are bound to the objects
+// of the target cluster on import. The digest of the configuration is left out as well.
+func (c *Client) ExportConfig(ctx context.Context, zone string) ([]byte, error) {
+	body, err := c.Get(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	body.Digest = nil
+	body.Delete = nil
+	(&SdnZoneBindings{}).bind(body)
+
+	data, err := json.MarshalIndent(&SdnZoneConfig{Version: zoneConfigVersion, Zone: body}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encoding SDN zone %s configuration: %w", zone, err)
+	}
+
+	return data, nil
+}
+
+// ImportConfig creates a SDN zone from a portable JSON document returned by ExportConfig, bound to the
+// nodes and the SDN objects of this cluster, and returns the configuration of the created zone.
+// The bindings may be nil for the zones that don't need any, e.g. a simple zone spanning all nodes.
+func (c *Client) ImportConfig(ctx context.Context, data []byte, bindings *SdnZoneBindings) (*SdnZoneBody, error) {
+	config := &SdnZoneConfig{}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("error decoding SDN zone configuration: %w", err)
+	}
+
+	if config.Version != zoneConfigVersion {
+		return nil, fmt.Errorf("unsupported SDN zone configuration version %d", config.Version)
+	}
+
+	body := config.Zone
+	if body == nil || body.Name == "" {
+		return nil, fmt.Errorf("SDN zone configuration has no zone name")
+	}
+
+	if body.Type == nil || !slices.Contains(supportedZoneTypes, *body.Type) {
+		return nil, fmt.Errorf("SDN zone %s has an unsupported type, expected one of: %s",
+			body.Name, strings.Join(supportedZoneTypes, ", "))
+	}
+
+	body.Digest = nil
+	body.Delete = nil
+
+	if bindings == nil {
+		bindings = &SdnZoneBindings{}
+	}
+
+	bindings.bind(body)
+
+	if err := c.Create(ctx, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// bind sets the cluster-specific attributes of a zone to the bindings, unsetting the ones without a binding.
+func (b *SdnZoneBindings) bind(body *SdnZoneBody) {
+	body.Nodes = b.Nodes
+	body.Exitnodes = b.Exitnodes
+	body.ExitnodesPrimary = b.ExitnodesPrimary
+	body.Peers = b.Peers
+	body.Controller = b.Controller
+	body.Ipam = b.Ipam
+	body.Dns = b.Dns
+	body.Reversedns = b.Reversedns
+}
@@ -0,0 +1,129 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// errStopIteration is returned by the streaming decoder when the callback fails, to abort decoding.
+var errStopIteration = errors.New("iteration stopped")
+
+// ForEach calls fn for every SDN zone of the cluster, in the order provided by the API.
+// Unlike List, the zones are decoded and passed to fn one at a time, without building and sorting
+// the whole list. The Proxmox API doesn't paginate, so the raw response is still read at once.
+// The iteration stops at the first error returned by fn, which is then returned as is.
+func (c *Client) ForEach(ctx context.Context, fn func(*SdnZoneBody) error) error {
+	resBody := &sdnZoneStreamResponseBody{fn: fn}
+
+	err := c.doRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if resBody.err != nil {
+		return resBody.err
+	}
+
+	if err != nil {
+		return fmt.Errorf("error listing SDN zones: %w", err)
+	}
+
+	if !resBody.hasData {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	return nil
+}
+
+// sdnZoneStreamResponseBody decodes a SDN zones list response, passing the zones to a callback
+// as they are decoded.
+type sdnZoneStreamResponseBody struct {
+	fn      func(*SdnZoneBody) error
+	err     error
+	hasData bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *sdnZoneStreamResponseBody) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key != "data" {
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := b.decodeData(dec); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func (b *sdnZoneStreamResponseBody) decodeData(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	// A null list carries no data, same as a missing one.
+	if tok == nil {
+		return nil
+	}
+
+	if tok != json.Delim('[') {
+		return fmt.Errorf("unexpected JSON token %v, expected a list of SDN zones", tok)
+	}
+
+	b.hasData = true
+
+	for dec.More() {
+		zone := &SdnZoneBody{}
+		if err := dec.Decode(zone); err != nil {
+			return err
+		}
+
+		if err := b.fn(zone); err != nil {
+			b.err = err
+			return errStopIteration
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token, which must be the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("unexpected JSON token %v, expected %v", tok, delim)
+	}
+
+	return nil
+}
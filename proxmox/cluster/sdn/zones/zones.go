@@ -11,36 +11,156 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
+// ListOption is an option for listing SDN zones.
+type ListOption interface {
+	apply(opts *listOptions)
+}
+
+type listOptions struct {
+	sortByType  bool
+	unsorted    bool
+	summaryOnly bool
+	ipam        *string
+}
+
+type withSortByType struct{}
+
+// WithSortByType is an option to sort the listed SDN zones by type, and by name within the same type.
+func WithSortByType() ListOption {
+	return withSortByType{}
+}
+
+func (w withSortByType) apply(opts *listOptions) {
+	opts.sortByType = true
+}
+
+type withoutSorting struct{}
+
+// WithoutSorting is an option to return the SDN zones in the order provided by the API.
+func WithoutSorting() ListOption {
+	return withoutSorting{}
+}
+
+func (w withoutSorting) apply(opts *listOptions) {
+	opts.unsorted = true
+}
+
+type withSummaryOnly struct{}
+
+// WithSummaryOnly is an option to only decode the name and the type of the listed SDN zones,
+// leaving all other fields nil. The Proxmox API can't select the returned fields, so the payload
+// stays the same, but decoding is cheaper when only the zones need to be enumerated.
+func WithSummaryOnly() ListOption {
+	return withSummaryOnly{}
+}
+
+func (w withSummaryOnly) apply(opts *listOptions) {
+	opts.summaryOnly = true
+}
+
+type withIPAM struct {
+	ipam string
+}
+
+// WithIPAM is an option to only list the SDN zones using the given IPAM. An empty name selects the zones
+// without IPAM. Proxmox doesn't assign the built-in `pve` IPAM to zones implicitly, so `pve` only selects
+// the zones setting it explicitly.
+func WithIPAM(ipam string) ListOption {
+	return withIPAM{ipam: ipam}
+}
+
+func (w withIPAM) apply(opts *listOptions) {
+	opts.ipam = &w.ipam
+}
+
 // List returns a list of SDN zones in the Proxmox cluster.
-func (c *Client) List(ctx context.Context) ([]*SdnZoneBody, error) {
+// The zones are sorted by name, unless specified otherwise by the options.
+func (c *Client) List(ctx context.Context, opts ...ListOption) ([]*SdnZoneBody, error) {
+	options := &listOptions{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
 	resBody := &SdnZoneListResponseBody{}
 
-	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
-	if err != nil {
-		return nil, fmt.Errorf("error listing SDN zones: %w", err)
+	if options.summaryOnly {
+		summaryBody := &SdnZoneSummaryListResponseBody{}
+
+		err := c.doRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, summaryBody)
+		if err != nil {
+			return nil, fmt.Errorf("error listing SDN zones: %w", err)
+		}
+
+		if summaryBody.Data != nil {
+			resBody.Data = make([]*SdnZoneBody, 0, len(summaryBody.Data))
+			for _, summary := range summaryBody.Data {
+				if options.ipam == nil || ptr.Or(summary.Ipam, "") == *options.ipam {
+					resBody.Data = append(resBody.Data, &SdnZoneBody{Name: summary.Name, Type: summary.Type})
+				}
+			}
+		}
+	} else {
+		err := c.doRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+		if err != nil {
+			return nil, fmt.Errorf("error listing SDN zones: %w", err)
+		}
+
+		if options.ipam != nil && resBody.Data != nil {
+			resBody.Data = slices.DeleteFunc(resBody.Data, func(zone *SdnZoneBody) bool {
+				return ptr.Or(zone.Ipam, "") != *options.ipam
+			})
+		}
 	}
 
 	if resBody.Data == nil {
 		return nil, api.ErrNoDataObjectInResponse
 	}
 
-	sort.Slice(resBody.Data, func(i, j int) bool {
-		return resBody.Data[i].Name < resBody.Data[j].Name
-	})
+	switch {
+	case options.unsorted:
+		// keep the order provided by the API
+	case options.sortByType:
+		sort.Slice(resBody.Data, func(i, j int) bool {
+			ti, tj := ptr.Or(resBody.Data[i].Type, ""), ptr.Or(resBody.Data[j].Type, "")
+			if ti != tj {
+				return ti < tj
+			}
+
+			return resBody.Data[i].Name < resBody.Data[j].Name
+		})
+	default:
+		sort.Slice(resBody.Data, func(i, j int) bool {
+			return resBody.Data[i].Name < resBody.Data[j].Name
+		})
+	}
 
 	return resBody.Data, nil
 }
 
-// Get retrieves a single SDN zone based on its identifier.
+// Get retrieves a single SDN zone based on its identifier. Depending on the Proxmox version, the zone is read
+// from the running or the staged configuration, use GetRunning or GetPending to choose the view explicitly.
 func (c *Client) Get(ctx context.Context, zone string) (*SdnZoneBody, error) {
+	return c.get(ctx, zone, nil)
+}
+
+// GetRunning retrieves the applied configuration of a SDN zone, ignoring its staged changes. A zone that
+// is created but not applied yet doesn't exist in this view.
+func (c *Client) GetRunning(ctx context.Context, zone string) (*SdnZoneBody, error) {
+	return c.get(ctx, zone, &SdnZoneGetRequestBody{Running: types.CustomBool(true).Pointer()})
+}
+
+func (c *Client) get(ctx context.Context, zone string, reqBody *SdnZoneGetRequestBody) (*SdnZoneBody, error) {
 	resBody := &SdnZoneGetResponseBody{}
 
-	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(zone)), nil, resBody)
+	err := c.doRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(zone)), reqBody, resBody)
 	if err != nil {
 		return nil, fmt.Errorf("error reading SDN zone: %w", err)
 	}
@@ -54,7 +174,7 @@ func (c *Client) Get(ctx context.Context, zone string) (*SdnZoneBody, error) {
 
 // Create creates a new SDN zone.
 func (c *Client) Create(ctx context.Context, data *SdnZoneBody) error {
-	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	err := c.doWithLockRetry(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
 	if err != nil {
 		return fmt.Errorf("error creating SDN zone: %w", err)
 	}
@@ -64,7 +184,7 @@ func (c *Client) Create(ctx context.Context, data *SdnZoneBody) error {
 
 // Update updates an existing SDN zone.
 func (c *Client) Update(ctx context.Context, zone string, data *SdnZoneBody) error {
-	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(zone)), data, nil)
+	err := c.doWithLockRetry(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(zone)), data, nil)
 	if err != nil {
 		return fmt.Errorf("error updating SDN zone: %w", err)
 	}
@@ -74,7 +194,7 @@ func (c *Client) Update(ctx context.Context, zone string, data *SdnZoneBody) err
 
 // Delete removes an SDN zone.
 func (c *Client) Delete(ctx context.Context, zone string) error {
-	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(zone)), nil, nil)
+	err := c.doWithLockRetry(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(zone)), nil, nil)
 	if err != nil {
 		return fmt.Errorf("error deleting SDN zone: %w", err)
 	}
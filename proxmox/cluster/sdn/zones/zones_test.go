@@ -0,0 +1,678 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package zones
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+)
+
+func TestCRUD(t *testing.T) {
+	t.Parallel()
+
+	server, client := newMockServer(t)
+
+	err := client.Create(t.Context(), &SdnZoneBody{
+		Name:  "zone1",
+		Type:  ptr.Ptr("vxlan"),
+		Peers: ptr.Ptr("10.0.0.1,10.0.0.2"),
+		Mtu:   ptr.Ptr(int32(1450)),
+	})
+	require.NoError(t, err)
+
+	zone, err := client.Get(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, "vxlan", *zone.Type)
+	require.Equal(t, int32(1450), *zone.Mtu)
+
+	err = client.Update(t.Context(), "zone1", &SdnZoneBody{
+		Name:   "zone1",
+		Peers:  ptr.Ptr("10.0.0.3"),
+		Delete: ptr.Ptr("mtu"),
+	})
+	require.NoError(t, err)
+
+	zone, err = client.Get(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.3", *zone.Peers)
+	require.Nil(t, zone.Mtu)
+
+	err = client.Delete(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Nil(t, server.zone("zone1"))
+
+	_, err = client.Get(t.Context(), "zone1")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+	require.Equal(t, []string{"POST", "GET zone1", "PUT zone1", "GET zone1", "DELETE zone1", "GET zone1"}, server.calls())
+}
+
+func TestCreateValidationError(t *testing.T) {
+	t.Parallel()
+
+	server, client := newMockServer(t)
+	server.failNextValidation(map[string]string{"mtu": "value must have a minimum value of 0"})
+
+	err := client.Create(t.Context(), &SdnZoneBody{Name: "zone1", Type: ptr.Ptr("simple"), Mtu: ptr.Ptr(int32(-1))})
+	require.ErrorContains(t, err, "Parameter verification failed.")
+	require.ErrorContains(t, err, "mtu: value must have a minimum value of 0")
+	require.Nil(t, server.zone("zone1"))
+
+	var httpErr *api.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, map[string]string{"mtu": "value must have a minimum value of 0"}, httpErr.Errors)
+}
+
+func TestDeleteRetriesOnLockError(t *testing.T) {
+	lockRetryDelay = 10 * time.Millisecond
+
+	server, client := newMockServer(t, map[string]any{"zone": "zone1", "type": "simple"})
+	server.failNextLocked()
+	server.failNextLocked()
+
+	err := client.Delete(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"DELETE zone1", "DELETE zone1", "DELETE zone1"}, server.calls())
+	require.Nil(t, server.zone("zone1"))
+}
+
+func TestDeleteDoesNotRetryOtherErrors(t *testing.T) {
+	lockRetryDelay = 10 * time.Millisecond
+
+	server, client := newMockServer(t)
+	server.failNextNotFound("zone1")
+
+	err := client.Delete(t.Context(), "zone1")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+	require.Equal(t, []string{"DELETE zone1"}, server.calls())
+}
+
+func TestListSortOrder(t *testing.T) {
+	t.Parallel()
+
+	_, client := newMockServer(t,
+		map[string]any{"zone": "zone3", "type": "simple"},
+		map[string]any{"zone": "zone1", "type": "vlan"},
+		map[string]any{"zone": "zone2", "type": "simple"},
+	)
+
+	names := func(list []*SdnZoneBody) []string {
+		result := make([]string, len(list))
+		for i, z := range list {
+			result[i] = z.Name
+		}
+
+		return result
+	}
+
+	list, err := client.List(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []string{"zone1", "zone2", "zone3"}, names(list))
+
+	list, err = client.List(t.Context(), WithSortByType())
+	require.NoError(t, err)
+	require.Equal(t, []string{"zone2", "zone3", "zone1"}, names(list))
+
+	list, err = client.List(t.Context(), WithoutSorting())
+	require.NoError(t, err)
+	require.Equal(t, []string{"zone3", "zone1", "zone2"}, names(list))
+}
+
+func TestListSummaryOnly(t *testing.T) {
+	t.Parallel()
+
+	_, client := newMockServer(t,
+		map[string]any{"zone": "zone2", "type": "vxlan", "peers": "10.0.0.1", "mtu": 1450},
+		map[string]any{"zone": "zone1", "type": "simple", "ipam": "pve"},
+	)
+
+	list, err := client.List(t.Context(), WithSummaryOnly())
+	require.NoError(t, err)
+	require.Equal(t, []*SdnZoneBody{
+		{Name: "zone1", Type: ptr.Ptr("simple")},
+		{Name: "zone2", Type: ptr.Ptr("vxlan")},
+	}, list)
+}
+
+func TestListByIPAM(t *testing.T) {
+	t.Parallel()
+
+	_, client := newMockServer(t,
+		map[string]any{"zone": "zone4", "type": "simple", "ipam": "netbox"},
+		map[string]any{"zone": "zone3", "type": "simple"},
+		map[string]any{"zone": "zone2", "type": "vlan", "bridge": "vmbr0", "ipam": "pve"},
+		map[string]any{"zone": "zone1", "type": "simple", "ipam": "netbox"},
+	)
+
+	names := func(list []*SdnZoneBody) []string {
+		result := make([]string, len(list))
+		for i, z := range list {
+			result[i] = z.Name
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name  string
+		ipam  string
+		opts  []ListOption
+		zones []string
+	}{
+		{"sorted", "netbox", nil, []string{"zone1", "zone4"}},
+		{"unsorted", "netbox", []ListOption{WithoutSorting()}, []string{"zone4", "zone1"}},
+		{"summary", "netbox", []ListOption{WithSummaryOnly()}, []string{"zone1", "zone4"}},
+		{"built-in IPAM", "pve", nil, []string{"zone2"}},
+		{"without IPAM", "", nil, []string{"zone3"}},
+		{"without IPAM summary", "", []ListOption{WithSummaryOnly()}, []string{"zone3"}},
+		{"unknown IPAM", "phpipam", nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			list, err := client.List(t.Context(), append(tt.opts, WithIPAM(tt.ipam))...)
+			require.NoError(t, err)
+			require.Equal(t, tt.zones, names(list))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api2/json/cluster/sdn/zones/zone1", r.URL.Path)
+
+		switch {
+		case r.URL.Query().Get("running") == "1":
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"vxlan","mtu":1450,"peers":"10.0.0.1","digest":"a"}}`))
+		case r.URL.Query().Get("pending") == "1":
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"vxlan","mtu":1450,"peers":"10.0.0.1","digest":"b",` +
+				`"state":"changed","pending":{"mtu":9000,"ipam":"pve"}}}`))
+		default:
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+	})
+
+	diff, err := client.Diff(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.NotNil(t, diff.State)
+	require.Equal(t, "changed", *diff.State)
+	require.Equal(t, []SdnZoneFieldChange{
+		{Field: "ipam", Running: nil, Pending: "pve"},
+		{Field: "mtu", Running: float64(1450), Pending: float64(9000)},
+	}, diff.Changes)
+}
+
+func TestDiffNotAppliedZone(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("running") == "1" {
+			writeStatus(t, w, http.StatusInternalServerError, "sdn 'zone1' does not exist")
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"simple","state":"new"}}`))
+	})
+
+	diff, err := client.Diff(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, "new", *diff.State)
+	require.Equal(t, []SdnZoneFieldChange{
+		{Field: "type", Pending: "simple"},
+		{Field: "zone", Pending: "zone1"},
+	}, diff.Changes)
+}
+
+func TestGetViews(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api2/json/cluster/sdn/zones/zone1", r.URL.Path)
+
+		switch {
+		case r.URL.Query().Get("running") == "1":
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"vxlan","mtu":1450,"peers":"10.0.0.1","digest":"a"}}`))
+		case r.URL.Query().Get("pending") == "1":
+			_, _ = w.Write([]byte(`{"data":{"zone":"zone1","type":"vxlan","mtu":1450,"peers":"10.0.0.1","digest":"b",` +
+				`"state":"changed","pending":{"mtu":9000,"ipam":"pve","peers":null}}}`))
+		default:
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+	})
+
+	running, err := client.GetRunning(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, &SdnZoneBody{
+		Name:   "zone1",
+		Type:   ptr.Ptr("vxlan"),
+		Mtu:    ptr.Ptr(int32(1450)),
+		Peers:  ptr.Ptr("10.0.0.1"),
+		Digest: ptr.Ptr("a"),
+	}, running)
+
+	pending, err := client.GetPending(t.Context(), "zone1")
+	require.NoError(t, err)
+	require.Equal(t, &SdnZoneBody{
+		Name:   "zone1",
+		Type:   ptr.Ptr("vxlan"),
+		Mtu:    ptr.Ptr(int32(9000)),
+		Ipam:   ptr.Ptr("pve"),
+		Digest: ptr.Ptr("b"),
+	}, pending)
+}
+
+func TestGetViewsOfUnappliedZones(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		state string
+	}{
+		{"created", "new"},
+		{"deleted", PendingStateDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("running") == "1" {
+					writeStatus(t, w, http.StatusInternalServerError, "sdn 'zone1' does not exist")
+					return
+				}
+
+				_, _ = fmt.Fprintf(w, `{"data":{"zone":"zone1","type":"simple","state":%q}}`, tt.state)
+			})
+
+			_, err := client.GetRunning(t.Context(), "zone1")
+			require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+			pending, err := client.GetPending(t.Context(), "zone1")
+			if tt.state == PendingStateDeleted {
+				require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, &SdnZoneBody{Name: "zone1", Type: ptr.Ptr("simple")}, pending)
+		})
+	}
+}
+
+func TestPendingState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		body  string
+		state string
+	}{
+		{"applied", `{"data":{"zone":"zone1","type":"simple"}}`, ""},
+		{"deleted", `{"data":{"zone":"zone1","type":"simple","state":"deleted"}}`, PendingStateDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "1", r.URL.Query().Get("pending"))
+
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			state, err := client.PendingState(t.Context(), "zone1")
+			require.NoError(t, err)
+			require.Equal(t, tt.state, state)
+		})
+	}
+}
+
+func TestSDNNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		code         int
+		reason       string
+		notAvailable bool
+	}{
+		{"method not implemented", http.StatusNotImplemented, "Method 'GET /cluster/sdn/zones' not implemented", true},
+		{"missing configuration", http.StatusInternalServerError,
+			"unable to open file '/etc/pve/sdn/zones.cfg' - No such file or directory", true},
+		{"unrelated missing file", http.StatusInternalServerError,
+			"unable to open file '/etc/pve/nodes/pve1/config' - No such file or directory", false},
+		{"unrelated not implemented", http.StatusInternalServerError, "feature 'foo' not implemented", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, client := newMockServer(t)
+			server.failNext(tt.code, tt.reason)
+
+			_, err := client.List(t.Context())
+			require.Error(t, err)
+
+			if tt.notAvailable {
+				require.ErrorIs(t, err, ErrSDNNotAvailable)
+			} else {
+				require.NotErrorIs(t, err, ErrSDNNotAvailable)
+			}
+		})
+	}
+}
+
+func TestCreateDoesNotRetryWhenSDNNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	server, client := newMockServer(t)
+	server.failNext(http.StatusInternalServerError, "can't lock file '/etc/pve/sdn/.lock' - No such file or directory")
+	server.failNext(http.StatusInternalServerError, "can't lock file '/etc/pve/sdn/.lock' - No such file or directory")
+
+	err := client.Create(t.Context(), &SdnZoneBody{Name: "zone1", Type: ptr.Ptr("simple")})
+	require.ErrorIs(t, err, ErrSDNNotAvailable)
+	require.Equal(t, []string{"POST"}, server.calls())
+}
+
+func TestSerializedWrites(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, _ = w.Write([]byte(`{"data":null}`))
+	})
+
+	var wg sync.WaitGroup
+
+	for i := range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Every write gets its own client, the serialization must span all of them.
+			zones := NewClient(client.Client, WithSerializedWrites())
+			assert.NoError(t, zones.Update(t.Context(), fmt.Sprintf("zone%d", i), &SdnZoneBody{}))
+		}()
+	}
+
+	wg.Wait()
+	require.Equal(t, int32(1), maxInFlight.Load())
+}
+
+// TestSerializedWritesCanceled is not parallel, as it holds the write slot shared by all clients.
+func TestSerializedWritesCanceled(t *testing.T) {
+	server, client := newMockServer(t)
+	zones := NewClient(client.Client, WithSerializedWrites())
+
+	writeSlot <- struct{}{}
+	defer func() { <-writeSlot }()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	err := zones.Create(ctx, &SdnZoneBody{Name: "zone1", Type: ptr.Ptr("simple")})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Empty(t, server.calls())
+}
+
+func TestExportImportConfig(t *testing.T) {
+	t.Parallel()
+
+	_, source := newMockServer(t, map[string]any{
+		"zone":       "zone1",
+		"type":       "evpn",
+		"controller": "evpn1",
+		"vrf-vxlan":  10000,
+		"mtu":        1450,
+		"nodes":      "pve2,pve1",
+		"exitnodes":  "pve1",
+		"ipam":       "pve",
+		"dns":        "powerdns1",
+		"reversedns": "powerdns1",
+		"dnszone":    "example.com",
+		"digest":     "abc",
+	})
+
+	data, err := source.ExportConfig(t.Context(), "zone1")
+	require.NoError(t, err)
+
+	// The attributes referring to the source cluster are removed, the others are kept.
+	config := &SdnZoneConfig{}
+	require.NoError(t, json.Unmarshal(data, config))
+	require.Equal(t, &SdnZoneBody{
+		Name:     "zone1",
+		Type:     ptr.Ptr("evpn"),
+		VrfVxlan: ptr.Ptr(int32(10000)),
+		Mtu:      ptr.Ptr(int32(1450)),
+		Dnszone:  ptr.Ptr("example.com"),
+	}, config.Zone)
+
+	target, client := newMockServer(t)
+
+	zone, err := client.ImportConfig(t.Context(), data, &SdnZoneBindings{
+		Nodes:      ptr.Ptr("node1,node2"),
+		Controller: ptr.Ptr("evpn2"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "zone1", zone.Name)
+	require.Equal(t, map[string]any{
+		"zone":       "zone1",
+		"type":       "evpn",
+		"controller": "evpn2",
+		"vrf-vxlan":  10000,
+		"mtu":        1450,
+		"nodes":      "node1,node2",
+		"dnszone":    "example.com",
+	}, target.zone("zone1"))
+
+	// Without bindings, the zone is created without the cluster-specific attributes.
+	target, client = newMockServer(t)
+
+	_, err = client.ImportConfig(t.Context(), data, nil)
+	require.NoError(t, err)
+	require.NotContains(t, target.zone("zone1"), "nodes")
+	require.NotContains(t, target.zone("zone1"), "controller")
+}
+
+func TestImportConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"invalid document", `[]`, "error decoding SDN zone configuration"},
+		{"unsupported version", `{"version":2,"zone":{"zone":"zone1","type":"simple"}}`, "unsupported SDN zone configuration version 2"},
+		{"missing zone", `{"version":1}`, "no zone name"},
+		{"unsupported type", `{"version":1,"zone":{"zone":"zone1","type":"faucet"}}`, "unsupported type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, client := newMockServer(t)
+
+			_, err := client.ImportConfig(t.Context(), []byte(tt.data), nil)
+			require.ErrorContains(t, err, tt.err)
+			require.Empty(t, server.calls())
+		})
+	}
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	_, client := newMockServer(t,
+		map[string]any{"zone": "zone2", "type": "simple"},
+		map[string]any{"zone": "zone1", "type": "vlan", "bridge": "vmbr0"},
+		map[string]any{"zone": "zone3", "type": "simple"},
+	)
+
+	var names []string
+
+	err := client.ForEach(t.Context(), func(zone *SdnZoneBody) error {
+		names = append(names, zone.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"zone2", "zone1", "zone3"}, names, "zones must be passed in the API order")
+
+	stop := errors.New("stop")
+	names = nil
+
+	err = client.ForEach(t.Context(), func(zone *SdnZoneBody) error {
+		names = append(names, zone.Name)
+		if zone.Name == "zone1" {
+			return stop
+		}
+
+		return nil
+	})
+	require.Same(t, stop, err)
+	require.Equal(t, []string{"zone2", "zone1"}, names)
+}
+
+func TestForEachEmpty(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	err := client.ForEach(t.Context(), func(*SdnZoneBody) error {
+		return errors.New("unexpected zone")
+	})
+	require.NoError(t, err)
+
+	// Same as List, a response without data is an error.
+	_, client = newMockServer(t)
+
+	err = client.ForEach(t.Context(), func(*SdnZoneBody) error { return nil })
+	require.ErrorIs(t, err, api.ErrNoDataObjectInResponse)
+}
+
+func TestAddRemoveNodes(t *testing.T) {
+	t.Parallel()
+
+	server, client := newMockServer(t,
+		map[string]any{"zone": "zone1", "type": "simple", "nodes": "pve1,pve2"},
+		map[string]any{"zone": "zone2", "type": "simple"},
+		map[string]any{"zone": "zone3", "type": "simple", "nodes": "pve1, pve2,"},
+	)
+
+	require.NoError(t, client.AddNodes(t.Context(), "zone1", []string{"pve2", "pve3"}))
+	require.Equal(t, "pve1,pve2,pve3", server.zone("zone1")["nodes"])
+
+	require.NoError(t, client.RemoveNodes(t.Context(), "zone1", []string{"pve1", "pve4"}))
+	require.Equal(t, "pve2,pve3", server.zone("zone1")["nodes"])
+
+	// Nothing to change, the zone isn't updated.
+	require.NoError(t, client.AddNodes(t.Context(), "zone1", []string{"pve2"}))
+
+	err := client.RemoveNodes(t.Context(), "zone1", []string{"pve2", "pve3"})
+	require.ErrorContains(t, err, "would make it span the whole cluster")
+	require.Equal(t, "pve2,pve3", server.zone("zone1")["nodes"])
+
+	err = client.RemoveNodes(t.Context(), "zone2", []string{"pve1"})
+	require.ErrorContains(t, err, "spans the whole cluster")
+
+	// The zone already spans the whole cluster, adding nodes must not restrict it to them.
+	require.NoError(t, client.AddNodes(t.Context(), "zone2", []string{"pve1"}))
+	require.Nil(t, server.zone("zone2")["nodes"])
+
+	require.NoError(t, client.AddNodes(t.Context(), "zone3", []string{"pve2", "pve3"}))
+	require.Equal(t, "pve1,pve2,pve3", server.zone("zone3")["nodes"])
+
+	require.Equal(t, []string{
+		"GET zone1", "PUT zone1",
+		"GET zone1", "PUT zone1",
+		"GET zone1",
+		"GET zone1",
+		"GET zone2",
+		"GET zone2",
+		"GET zone3", "PUT zone3",
+	}, server.calls())
+}
+
+func TestAddNodesMergesConcurrentEdit(t *testing.T) {
+	lockRetryDelay = 10 * time.Millisecond
+
+	server, client := newMockServer(t, map[string]any{"zone": "zone1", "type": "simple", "nodes": "pve1,pve2"})
+	server.editAfterNextGet(func(zone map[string]any) {
+		zone["nodes"] = "pve1,pve2,pve4"
+	})
+
+	require.NoError(t, client.AddNodes(t.Context(), "zone1", []string{"pve3"}))
+	require.Equal(t, "pve1,pve2,pve4,pve3", server.zone("zone1")["nodes"])
+	require.Equal(t, []string{"GET zone1", "PUT zone1", "GET zone1", "PUT zone1"}, server.calls())
+}
+
+func TestConcurrentNodeChanges(t *testing.T) {
+	t.Parallel()
+
+	server, client := newMockServer(t, map[string]any{"zone": "zone1", "type": "simple", "nodes": "pve1,pve2"})
+
+	var wg sync.WaitGroup
+
+	for i := 3; i <= 6; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, client.AddNodes(t.Context(), "zone1", []string{fmt.Sprintf("pve%d", i)}))
+		}()
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		assert.NoError(t, client.RemoveNodes(t.Context(), "zone1", []string{"pve1"}))
+	}()
+
+	wg.Wait()
+
+	require.ElementsMatch(t,
+		[]string{"pve2", "pve3", "pve4", "pve5", "pve6"},
+		strings.Split(server.zone("zone1")["nodes"].(string), ","),
+	)
+}
@@ -6,16 +6,87 @@
 
 package zones
 
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
 // SdnZonesListResponseBody contains the body from a SDN zones list response.
 type SdnZoneListResponseBody struct {
 	Data []*SdnZoneBody `json:"data,omitempty"`
 }
 
+// SdnZoneSummaryListResponseBody contains the body from a SDN zones list response, decoding only the zone
+// names and types, and the IPAMs to filter the zones by.
+type SdnZoneSummaryListResponseBody struct {
+	Data []*SdnZoneSummary `json:"data,omitempty"`
+}
+
+// SdnZoneSummary contains the name and the type of a SDN zone.
+type SdnZoneSummary struct {
+	Name string  `json:"zone"`
+	Type *string `json:"type,omitempty"`
+	Ipam *string `json:"ipam,omitempty"`
+}
+
 // SdnZoneGetResponseData contains the data from a SDN zone get response.
 type SdnZoneGetResponseBody struct {
 	Data *SdnZoneBody `json:"data,omitempty"`
 }
 
+// SdnZoneGetRequestBody contains the query parameters of a SDN zone get request.
+type SdnZoneGetRequestBody struct {
+	Pending *types.CustomBool `json:"pending,omitempty" url:"pending,omitempty,int"`
+	Running *types.CustomBool `json:"running,omitempty" url:"running,omitempty,int"`
+}
+
+// SdnZoneListRequestBody contains the filters of a SDN zones list request.
+type SdnZoneListRequestBody struct {
+	Type *string `json:"type,omitempty" url:"type,omitempty"`
+}
+
+// SdnZoneViewResponseBody contains the body from a SDN zone get response, decoded without a fixed schema
+// so that the running and pending views can be compared field by field.
+type SdnZoneViewResponseBody struct {
+	Data map[string]any `json:"data,omitempty"`
+}
+
+// SdnZoneDiff describes the changes of a SDN zone that are staged but not yet applied.
+type SdnZoneDiff struct {
+	// State is the pending state of the zone reported by Proxmox, e.g. "new", "changed" or "deleted".
+	State *string
+	// Changes lists the changed fields, sorted by field name.
+	Changes []SdnZoneFieldChange
+}
+
+// SdnZoneFieldChange describes a single field of a SDN zone whose pending value differs from the running one.
+// A nil value means that the field is not set in the corresponding view.
+type SdnZoneFieldChange struct {
+	Field   string
+	Running any
+	Pending any
+}
+
+// SdnZoneConfig is the portable configuration of a SDN zone, used to move zones between clusters.
+type SdnZoneConfig struct {
+	// Version is the version of the configuration format.
+	Version int `json:"version"`
+	// Zone is the configuration of the zone, without the cluster-specific attributes.
+	Zone *SdnZoneBody `json:"zone"`
+}
+
+// SdnZoneBindings are the cluster-specific attributes of a SDN zone, which refer to the nodes or to other
+// SDN objects of the cluster and are left out of its portable configuration. A nil attribute is left unset.
+type SdnZoneBindings struct {
+	Nodes            *string
+	Exitnodes        *string
+	ExitnodesPrimary *string
+	Peers            *string
+	Controller       *string
+	Ipam             *string
+	Dns              *string
+	Reversedns       *string
+}
+
 // SdnZoneBody represents the body of a SDN zone in Proxmox.
 // Documented in: https://pve.proxmox.com/pve-docs/api-viewer/#/cluster/sdn/zones
 type SdnZoneBody struct {
@@ -28,6 +99,7 @@ type SdnZoneBody struct {
 	BridgeDisableMacLearning *bool   `json:"bridge-disable-mac-learning,omitempty" url:"bridge-disable-mac-learning,omitempty"`
 	Controller               *string `json:"controller,omitempty" url:"controller,omitempty"`
 	Dhcp                     *string `json:"dhcp,omitempty" url:"dhcp,omitempty"`
+	Digest                   *string `json:"digest,omitempty" url:"digest,omitempty"`
 	DisableArpNdSuppression  *bool   `json:"disable-arp-nd-suppression,omitempty" url:"disable-arp-nd-suppression,omitempty"`
 	Dns                      *string `json:"dns,omitempty" url:"dns,omitempty"`
 	Dnszone                  *string `json:"dnszone,omitempty" url:"dnszone,omitempty"`
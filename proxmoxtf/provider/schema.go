@@ -27,6 +27,10 @@ const (
 	mkProviderRandomVMIDs         = "random_vm_ids"
 	mkProviderRandomVMIDStart     = "random_vm_id_start"
 	mkProviderRandomVMIDEnd       = "random_vm_id_end"
+	mkProviderSDNStrict           = "sdn_strict"
+	mkProviderSDNAPIValidation    = "sdn_api_validation"
+	mkProviderSDNQuietNotFound    = "sdn_quiet_not_found"
+	mkProviderSDNDefaultIPAM      = "sdn_default_ipam"
 	mkProviderSSH                 = "ssh"
 	mkProviderSSHUsername         = "username"
 	mkProviderSSHPassword         = "password"
@@ -260,5 +264,36 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The ending number for random VM / Container IDs.",
 			ValidateFunc: validation.IntBetween(100, 999999999),
 		},
+		mkProviderSDNAPIValidation: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to validate the SDN resources against the existing cluster " +
+				"configuration, e.g. that the bridge of a VLAN zone exists on the zone nodes. " +
+				"Requires extra API calls during planning. Failed validations are reported " +
+				"as warnings, unless `sdn_strict` is set, e.g. new SDN subnets overlapping with " +
+				"existing ones of the same zone, except for new EVPN zones colliding with existing " +
+				"ones, which are always errors. Defaults to `false`.",
+		},
+		mkProviderSDNDefaultIPAM: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The IPAM of the SDN zones not setting `ipam` explicitly, overriding the " +
+				"built-in `pve` IPAM. Defaults to `pve`.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		mkProviderSDNQuietNotFound: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to remove the SDN objects deleted outside of Terraform from the state " +
+				"silently, instead of reporting a warning when refreshing or deleting them. Defaults to `false`.",
+		},
+		mkProviderSDNStrict: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to treat the warnings of the SDN resources and data sources, " +
+				"e.g. about SDN objects that vanished outside of Terraform, as errors. Failures of the extra API " +
+				"calls made only for validations or optional computed values, e.g. because of missing privileges, " +
+				"stay warnings. Defaults to `false`.",
+		},
 	}
 }